@@ -0,0 +1,72 @@
+package bitarray
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+)
+
+// AtomicBitArray — битовый массив фиксированного размера, биты которого
+// можно менять из нескольких горутин без внешней синхронизации.
+type AtomicBitArray struct {
+	data []atomic.Uint64
+	size uint
+}
+
+// NewAtomicBitArray создает атомарный битовый массив.
+// Паникует, если bitCnt больше math.MaxUint-63.
+func NewAtomicBitArray(bitCnt uint) *AtomicBitArray {
+	if bitCnt > maxBitCnt {
+		panic(fmt.Errorf("%w: %d bits", ErrOutOfRange, bitCnt))
+	}
+	return &AtomicBitArray{
+		data: make([]atomic.Uint64, wordsFor(bitCnt)),
+		size: bitCnt,
+	}
+}
+
+// Len возвращает размер массива в битах.
+func (b *AtomicBitArray) Len() uint { return b.size }
+
+// word возвращает слово и маску бита. Паникует, если индекс за пределами массива.
+func (b *AtomicBitArray) word(bitIdx uint) (*atomic.Uint64, uint64) {
+	if bitIdx >= b.size {
+		panic(fmt.Errorf("%w: %d >= %d", ErrOutOfRange, bitIdx, b.size))
+	}
+	return &b.data[bitIdx/wordSize], 1 << (bitIdx % wordSize)
+}
+
+// Set устанавливает бит.
+func (b *AtomicBitArray) Set(bitIdx uint) {
+	w, mask := b.word(bitIdx)
+	w.Or(mask)
+}
+
+// Clear сбрасывает бит.
+func (b *AtomicBitArray) Clear(bitIdx uint) {
+	w, mask := b.word(bitIdx)
+	w.And(^mask)
+}
+
+// TestAndSet устанавливает бит и возвращает его предыдущее значение.
+func (b *AtomicBitArray) TestAndSet(bitIdx uint) bool {
+	w, mask := b.word(bitIdx)
+	return w.Or(mask)&mask != 0
+}
+
+// Get возвращает бит.
+func (b *AtomicBitArray) Get(bitIdx uint) bool {
+	w, mask := b.word(bitIdx)
+	return w.Load()&mask != 0
+}
+
+// Count возвращает количество установленных бит.
+// При конкурентных изменениях результат соответствует пословному снимку,
+// а не одному моменту времени.
+func (b *AtomicBitArray) Count() uint {
+	cnt := 0
+	for i := range b.data {
+		cnt += bits.OnesCount64(b.data[i].Load())
+	}
+	return uint(cnt)
+}
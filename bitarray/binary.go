@@ -0,0 +1,269 @@
+package bitarray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"unsafe"
+)
+
+// Формат сериализации:
+//
+//	0  [4]byte  magic "XBIT"
+//	4  uint8    версия формата
+//	5  uint8    порядок байт в словах (0 — little-endian)
+//	6  uint16   зарезервировано
+//	8  uint64   размер массива в битах
+//	16 uint32   CRC-32C слов данных
+//	20 uint32   зарезервировано
+//	24 []uint64 слова данных
+//
+// Заголовок занимает 24 байта, поэтому слова данных выровнены на 8 байт
+// и отображенный в память файл можно использовать через View без копирования.
+const (
+	binaryMagic      = "XBIT"
+	binaryVersion    = 1
+	binaryHeaderSize = 24
+
+	wordOrderLittleEndian = 0
+
+	// readChunkWords — сколько слов ReadFrom читает за раз. Память под данные
+	// выделяется по мере чтения, а не по размеру из заголовка.
+	readChunkWords = 8192
+)
+
+// ErrInvalidFormat возвращается при разборе поврежденных или чужих данных.
+var ErrInvalidFormat = errors.New("bitarray: invalid format")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// hostLittleEndian — совпадает ли порядок байт машины с порядком байт формата.
+var hostLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// header — разобранный заголовок сериализованного массива.
+type header struct {
+	size     uint
+	checksum uint32
+}
+
+func (b *BitArray) appendHeader(dst []byte) []byte {
+	dst = append(dst, binaryMagic...)
+	dst = append(dst, binaryVersion, wordOrderLittleEndian, 0, 0)
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(b.size))
+	dst = binary.LittleEndian.AppendUint32(dst, b.checksum())
+	return binary.LittleEndian.AppendUint32(dst, 0)
+}
+
+func parseHeader(data []byte) (header, error) {
+	if len(data) < binaryHeaderSize {
+		return header{}, fmt.Errorf("%w: short header", ErrInvalidFormat)
+	}
+	if string(data[:4]) != binaryMagic {
+		return header{}, fmt.Errorf("%w: bad magic %q", ErrInvalidFormat, data[:4])
+	}
+	if data[4] != binaryVersion {
+		return header{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, data[4])
+	}
+	if data[5] != wordOrderLittleEndian {
+		return header{}, fmt.Errorf("%w: unsupported word order %d", ErrInvalidFormat, data[5])
+	}
+	size := binary.LittleEndian.Uint64(data[8:])
+	if size > maxBitCnt {
+		return header{}, fmt.Errorf("%w: size %d too large", ErrInvalidFormat, size)
+	}
+	return header{
+		size:     uint(size),
+		checksum: binary.LittleEndian.Uint32(data[16:]),
+	}, nil
+}
+
+// eachPayloadChunk передает fn слова данных, закодированные в little-endian, порциями.
+func (b *BitArray) eachPayloadChunk(fn func([]byte) error) error {
+	if hostLittleEndian {
+		return fn(wordsAsBytes(b.data))
+	}
+
+	const chunkWords = 512
+	buf := make([]byte, 0, chunkWords*8)
+	for i := 0; i < len(b.data); i += chunkWords {
+		buf = buf[:0]
+		for _, w := range b.data[i:min(i+chunkWords, len(b.data))] {
+			buf = binary.LittleEndian.AppendUint64(buf, w)
+		}
+		if err := fn(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *BitArray) checksum() uint32 {
+	var crc uint32
+	b.eachPayloadChunk(func(p []byte) error {
+		crc = crc32.Update(crc, crcTable, p)
+		return nil
+	})
+	return crc
+}
+
+// MarshalBinary реализует encoding.BinaryMarshaler.
+func (b *BitArray) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(binaryHeaderSize + 8*len(b.data))
+	if _, err := b.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary реализует encoding.BinaryUnmarshaler. Данные копируются.
+func (b *BitArray) UnmarshalBinary(data []byte) error {
+	_, err := b.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo пишет заголовок и слова данных в w.
+func (b *BitArray) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.appendHeader(make([]byte, 0, binaryHeaderSize)))
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	err = b.eachPayloadChunk(func(p []byte) error {
+		n, err := w.Write(p)
+		total += int64(n)
+		return err
+	})
+	return total, err
+}
+
+// ReadFrom читает массив, записанный WriteTo, заменяя текущее содержимое.
+// Из r читается ровно один сериализованный массив.
+func (b *BitArray) ReadFrom(r io.Reader) (int64, error) {
+	hdr := make([]byte, binaryHeaderSize)
+	n, err := io.ReadFull(r, hdr)
+	total := int64(n)
+	if err != nil {
+		return total, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	h, err := parseHeader(hdr)
+	if err != nil {
+		return total, err
+	}
+
+	var (
+		words = wordsFor(h.size)
+		data  = make([]uint64, 0, min(words, readChunkWords))
+		buf   = make([]byte, 8*min(words, readChunkWords))
+		crc   uint32
+	)
+	for uint(len(data)) < words {
+		chunk := buf[:8*min(words-uint(len(data)), readChunkWords)]
+		n, err = io.ReadFull(r, chunk)
+		total += int64(n)
+		if err != nil {
+			return total, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		crc = crc32.Update(crc, crcTable, chunk)
+		for i := 0; i < len(chunk); i += 8 {
+			data = append(data, binary.LittleEndian.Uint64(chunk[i:]))
+		}
+	}
+	if crc != h.checksum {
+		return total, fmt.Errorf("%w: checksum mismatch", ErrInvalidFormat)
+	}
+
+	res := &BitArray{data: data, size: h.size}
+	if err := res.checkTail(); err != nil {
+		return total, err
+	}
+
+	b.data, b.size = res.data, res.size
+	return total, nil
+}
+
+// checkTail проверяет, что биты последнего слова за пределами size сброшены.
+func (b *BitArray) checkTail() error {
+	if n := len(b.data); n > 0 && b.data[n-1]&^b.lastWordMask() != 0 {
+		return fmt.Errorf("%w: bits set beyond size %d", ErrInvalidFormat, b.size)
+	}
+	return nil
+}
+
+// FromWords создает массив из bitCnt бит поверх words без копирования.
+// Изменения массива видны в words и наоборот. Увеличение через Resize
+// переносит массив в новую память, и связь с words теряется.
+func FromWords(words []uint64, bitCnt uint) (*BitArray, error) {
+	if bitCnt > maxBitCnt {
+		return nil, fmt.Errorf("%w: %d bits", ErrOutOfRange, bitCnt)
+	}
+	if uint(len(words)) < wordsFor(bitCnt) {
+		return nil, fmt.Errorf("%w: %d words can't hold %d bits", ErrOutOfRange, len(words), bitCnt)
+	}
+	// емкость обрезается, чтобы Resize не писал в память вызывающего за пределами вида
+	n := wordsFor(bitCnt)
+	b := &BitArray{data: words[:n:n], size: bitCnt}
+	if err := b.checkTail(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// FromBytes создает массив из bitCnt бит поверх сырых слов data без копирования.
+// data должен быть выровнен на 8 байт (как память от syscall.Mmap),
+// а порядок байт машины — little-endian.
+func FromBytes(data []byte, bitCnt uint) (*BitArray, error) {
+	words, err := bytesAsWords(data)
+	if err != nil {
+		return nil, err
+	}
+	return FromWords(words, bitCnt)
+}
+
+// View создает массив поверх данных, записанных WriteTo (например, файла,
+// отображенного в память через syscall.Mmap), без копирования слов.
+// Заголовок и контрольная сумма проверяются.
+func View(data []byte) (*BitArray, error) {
+	h, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := data[binaryHeaderSize:]
+	if need := 8 * wordsFor(h.size); uint(len(payload)) < need {
+		return nil, fmt.Errorf("%w: payload too short", ErrInvalidFormat)
+	} else {
+		payload = payload[:need]
+	}
+	if crc32.Checksum(payload, crcTable) != h.checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidFormat)
+	}
+	return FromBytes(payload, h.size)
+}
+
+// wordsAsBytes представляет слова как байты без копирования.
+func wordsAsBytes(words []uint64) []byte {
+	if len(words) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), 8*len(words))
+}
+
+// bytesAsWords представляет байты как слова без копирования.
+func bytesAsWords(data []byte) ([]uint64, error) {
+	if !hostLittleEndian {
+		return nil, fmt.Errorf("%w: zero-copy view requires little-endian host", ErrInvalidFormat)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if uintptr(unsafe.Pointer(&data[0]))%8 != 0 {
+		return nil, fmt.Errorf("%w: data is not 8-byte aligned", ErrInvalidFormat)
+	}
+	return unsafe.Slice((*uint64)(unsafe.Pointer(&data[0])), len(data)/8), nil
+}
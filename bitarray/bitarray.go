@@ -1,51 +1,138 @@
 package bitarray
 
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrOutOfRange возвращается при обращении к биту за пределами массива.
+var ErrOutOfRange = errors.New("bitarray: index out of range")
+
 type BitArrayI interface {
 	// Добавление бита по индексу
-	Set(idx int, val bool)
+	Set(idx uint, val bool)
 
 	// Получение бита по индексу
-	Get(idx int) bool
+	Get(idx uint) bool
+
+	// Добавление бита по индексу с проверкой границ
+	TrySet(idx uint, val bool) error
+
+	// Получение бита по индексу с проверкой границ
+	TryGet(idx uint) (bool, error)
+
+	// Размер массива в битах
+	Len() uint
 }
 
+var _ BitArrayI = (*BitArray)(nil)
+
+const (
+	wordSize  = 64                            // количество бит в слове хранилища
+	maxBitCnt = math.MaxUint - (wordSize - 1) // наибольший размер, для которого wordsFor не переполняется
+)
+
 type BitArray struct {
-	data []byte // биты храним здеся
-	size uint   // размер массива
+	data     []uint64 // биты храним здеся, бит i лежит в слове i/64; биты за size всегда нулевые
+	size     uint     // размер массива
+	growable bool     // растет ли массив при записи за его пределы
 }
 
 // Получаение нового биторого массива
+// Паникует, если bitCnt больше math.MaxUint-63.
 func NewBitArray(bitCnt uint) *BitArray {
+	if bitCnt > maxBitCnt {
+		panic(fmt.Errorf("%w: %d bits", ErrOutOfRange, bitCnt))
+	}
 	return &BitArray{
-		//в качестве длины слайса используется итоговое количество байт с учетом округления вверх
-		data: make([]byte, (bitCnt+7)/8),
+		//в качестве длины слайса используется итоговое количество слов с учетом округления вверх
+		data: make([]uint64, wordsFor(bitCnt)),
 		size: bitCnt,
 	}
 }
 
-func (b *BitArray) Set(bitIdx uint, val bool) {
+// NewGrowableBitArray создает массив, который расширяется при записи за его пределы.
+// Чтение за пределами такого массива возвращает false без ошибки.
+func NewGrowableBitArray(bitCnt uint) *BitArray {
+	b := NewBitArray(bitCnt)
+	b.growable = true
+	return b
+}
+
+// wordsFor возвращает количество слов для хранения bitCnt бит.
+func wordsFor(bitCnt uint) uint {
+	return (bitCnt + wordSize - 1) / wordSize
+}
+
+// Len возвращает размер массива в битах.
+func (b *BitArray) Len() uint { return b.size }
+
+// checkIdx возвращает ErrOutOfRange, если индекс за пределами массива.
+func (b *BitArray) checkIdx(bitIdx uint) error {
 	if bitIdx >= b.size {
-		panic("index out of range")
+		return fmt.Errorf("%w: %d >= %d", ErrOutOfRange, bitIdx, b.size)
 	}
+	return nil
+}
 
-	byteIdx := bitIdx / 8
-	bitOffset := uint(bitIdx % 8)
+// Set устанавливает бит по индексу. Паникует, если индекс за пределами массива.
+func (b *BitArray) Set(bitIdx uint, val bool) {
+	if err := b.TrySet(bitIdx, val); err != nil {
+		panic(err)
+	}
+}
+
+// Get возвращает бит по индексу. Паникует, если индекс за пределами массива.
+func (b *BitArray) Get(bitIdx uint) bool {
+	val, err := b.TryGet(bitIdx)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// TrySet устанавливает бит по индексу или возвращает ErrOutOfRange.
+// Растущий массив расширяется только при установке бита: сброс за пределами
+// ничего не делает, такие биты и так читаются как false.
+func (b *BitArray) TrySet(bitIdx uint, val bool) error {
+	if b.growable && bitIdx >= b.size {
+		if !val {
+			return nil
+		}
+		if bitIdx >= maxBitCnt {
+			return fmt.Errorf("%w: %d >= %d", ErrOutOfRange, bitIdx, uint(maxBitCnt))
+		}
+		b.Resize(bitIdx + 1)
+	}
+	if err := b.checkIdx(bitIdx); err != nil {
+		return err
+	}
+
+	wordIdx := bitIdx / wordSize
+	bitOffset := bitIdx % wordSize
 
 	if val {
 		// установить флаг
-		b.data[byteIdx] |= 1 << (bitOffset)
+		b.data[wordIdx] |= 1 << bitOffset
 	} else {
 		// сбросить флаг
-		b.data[byteIdx] &^= 1 << (bitOffset)
+		b.data[wordIdx] &^= 1 << bitOffset
 	}
+	return nil
 }
 
-func (b *BitArray) Get(bitIdx uint) bool {
-	if bitIdx >= b.size {
-		panic("index out of range")
+// TryGet возвращает бит по индексу или ErrOutOfRange.
+func (b *BitArray) TryGet(bitIdx uint) (bool, error) {
+	if b.growable && bitIdx >= b.size {
+		return false, nil
+	}
+	if err := b.checkIdx(bitIdx); err != nil {
+		return false, err
 	}
 
-	byteIdx := bitIdx / 8
-	bitOffset := uint(bitIdx % 8)
+	wordIdx := bitIdx / wordSize
+	bitOffset := bitIdx % wordSize
 
-	return b.data[byteIdx]&(1<<bitOffset) != 0
+	return b.data[wordIdx]&(1<<bitOffset) != 0, nil
 }
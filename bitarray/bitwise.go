@@ -0,0 +1,155 @@
+package bitarray
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSizeMismatch возвращается при побитовой операции над массивами разного размера.
+var ErrSizeMismatch = errors.New("bitarray: size mismatch")
+
+func (b *BitArray) checkSize(other *BitArray) error {
+	if b.size != other.size {
+		return fmt.Errorf("%w: %d != %d", ErrSizeMismatch, b.size, other.size)
+	}
+	return nil
+}
+
+// lastWordMask возвращает маску значащих бит последнего слова.
+func (b *BitArray) lastWordMask() uint64 {
+	if rem := b.size % wordSize; rem != 0 {
+		return 1<<rem - 1
+	}
+	return ^uint64(0)
+}
+
+// clearTail обнуляет биты последнего слова за пределами size.
+func (b *BitArray) clearTail() {
+	if n := len(b.data); n > 0 {
+		b.data[n-1] &= b.lastWordMask()
+	}
+}
+
+// Clone возвращает копию массива.
+func (b *BitArray) Clone() *BitArray {
+	return &BitArray{
+		data:     append([]uint64(nil), b.data...),
+		size:     b.size,
+		growable: b.growable,
+	}
+}
+
+// AndInPlace выполняет b &= other.
+func (b *BitArray) AndInPlace(other *BitArray) error {
+	if err := b.checkSize(other); err != nil {
+		return err
+	}
+	andWords(b.data, other.data)
+	return nil
+}
+
+// OrInPlace выполняет b |= other.
+func (b *BitArray) OrInPlace(other *BitArray) error {
+	if err := b.checkSize(other); err != nil {
+		return err
+	}
+	orWords(b.data, other.data)
+	return nil
+}
+
+// XorInPlace выполняет b ^= other.
+func (b *BitArray) XorInPlace(other *BitArray) error {
+	if err := b.checkSize(other); err != nil {
+		return err
+	}
+	xorWords(b.data, other.data)
+	return nil
+}
+
+// AndNotInPlace выполняет b &^= other (сбрасывает биты, установленные в other).
+func (b *BitArray) AndNotInPlace(other *BitArray) error {
+	if err := b.checkSize(other); err != nil {
+		return err
+	}
+	andNotWords(b.data, other.data)
+	return nil
+}
+
+// AndCount возвращает количество бит, установленных и в b, и в other,
+// не создавая промежуточный массив.
+func (b *BitArray) AndCount(other *BitArray) (uint, error) {
+	if err := b.checkSize(other); err != nil {
+		return 0, err
+	}
+	return uint(popcountAnd(b.data, other.data)), nil
+}
+
+// NotInPlace инвертирует все биты массива.
+func (b *BitArray) NotInPlace() {
+	for i := range b.data {
+		b.data[i] = ^b.data[i]
+	}
+	b.clearTail()
+}
+
+// And возвращает новый массив b & other.
+func (b *BitArray) And(other *BitArray) (*BitArray, error) {
+	return b.cloneApply(other, (*BitArray).AndInPlace)
+}
+
+// Or возвращает новый массив b | other.
+func (b *BitArray) Or(other *BitArray) (*BitArray, error) {
+	return b.cloneApply(other, (*BitArray).OrInPlace)
+}
+
+// Xor возвращает новый массив b ^ other.
+func (b *BitArray) Xor(other *BitArray) (*BitArray, error) {
+	return b.cloneApply(other, (*BitArray).XorInPlace)
+}
+
+// AndNot возвращает новый массив b &^ other.
+func (b *BitArray) AndNot(other *BitArray) (*BitArray, error) {
+	return b.cloneApply(other, (*BitArray).AndNotInPlace)
+}
+
+// Not возвращает новый массив с инвертированными битами.
+func (b *BitArray) Not() *BitArray {
+	res := b.Clone()
+	res.NotInPlace()
+	return res
+}
+
+func (b *BitArray) cloneApply(other *BitArray, op func(*BitArray, *BitArray) error) (*BitArray, error) {
+	if err := b.checkSize(other); err != nil {
+		return nil, err
+	}
+	res := b.Clone()
+	op(res, other)
+	return res, nil
+}
+
+// Equal сообщает, совпадают ли размеры и все биты массивов.
+func (b *BitArray) Equal(other *BitArray) bool {
+	if b.size != other.size {
+		return false
+	}
+	for i, w := range b.data {
+		if w != other.data[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset сообщает, установлены ли все биты b также и в other.
+func (b *BitArray) IsSubset(other *BitArray) (bool, error) {
+	if err := b.checkSize(other); err != nil {
+		return false, err
+	}
+	for i, w := range b.data {
+		if w&^other.data[i] != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
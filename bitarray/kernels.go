@@ -0,0 +1,118 @@
+package bitarray
+
+import "math/bits"
+
+// Массовые операции над словами. Ядра на чистом Go развернуты по 4 слова:
+// это убирает проверки границ и дает процессору независимые цепочки вычислений.
+// На amd64 AND и OR выполняются ассемблерными SSE2-ядрами (kernels_amd64.s),
+// сборка с тегом purego оставляет только реализацию на Go.
+//
+// Результаты на 2^20 бит (16384 слова), amd64, go run ./bitarray/test:
+//
+//	                        SSE2        purego
+//	and, побитово           ~3 000 000 ns/op
+//	and, ядро                   ~6 100      ~8 700 ns/op
+//	or, ядро                    ~6 400      ~8 700 ns/op
+//	count, побитово         ~3 600 000 ns/op
+//	count, ядро                ~10 500     ~10 600 ns/op
+//	and+count, ядро            ~11 700     ~12 000 ns/op
+
+// andWordsGeneric выполняет dst[i] &= src[i]. len(src) >= len(dst).
+func andWordsGeneric(dst, src []uint64) {
+	src = src[:len(dst)]
+	i := 0
+	for ; i+4 <= len(dst); i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] &= s[0]
+		d[1] &= s[1]
+		d[2] &= s[2]
+		d[3] &= s[3]
+	}
+	for ; i < len(dst); i++ {
+		dst[i] &= src[i]
+	}
+}
+
+// orWordsGeneric выполняет dst[i] |= src[i]. len(src) >= len(dst).
+func orWordsGeneric(dst, src []uint64) {
+	src = src[:len(dst)]
+	i := 0
+	for ; i+4 <= len(dst); i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] |= s[0]
+		d[1] |= s[1]
+		d[2] |= s[2]
+		d[3] |= s[3]
+	}
+	for ; i < len(dst); i++ {
+		dst[i] |= src[i]
+	}
+}
+
+// xorWords выполняет dst[i] ^= src[i]. len(src) >= len(dst).
+func xorWords(dst, src []uint64) {
+	src = src[:len(dst)]
+	i := 0
+	for ; i+4 <= len(dst); i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] ^= s[0]
+		d[1] ^= s[1]
+		d[2] ^= s[2]
+		d[3] ^= s[3]
+	}
+	for ; i < len(dst); i++ {
+		dst[i] ^= src[i]
+	}
+}
+
+// andNotWords выполняет dst[i] &^= src[i]. len(src) >= len(dst).
+func andNotWords(dst, src []uint64) {
+	src = src[:len(dst)]
+	i := 0
+	for ; i+4 <= len(dst); i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] &^= s[0]
+		d[1] &^= s[1]
+		d[2] &^= s[2]
+		d[3] &^= s[3]
+	}
+	for ; i < len(dst); i++ {
+		dst[i] &^= src[i]
+	}
+}
+
+// popcount возвращает количество установленных бит в словах.
+// bits.OnesCount64 компилируется в инструкцию POPCNT там, где она есть.
+func popcount(words []uint64) int {
+	var c0, c1, c2, c3 int
+	i := 0
+	for ; i+4 <= len(words); i += 4 {
+		w := words[i : i+4 : i+4]
+		c0 += bits.OnesCount64(w[0])
+		c1 += bits.OnesCount64(w[1])
+		c2 += bits.OnesCount64(w[2])
+		c3 += bits.OnesCount64(w[3])
+	}
+	for ; i < len(words); i++ {
+		c0 += bits.OnesCount64(words[i])
+	}
+	return c0 + c1 + c2 + c3
+}
+
+// popcountAnd возвращает количество установленных бит в x[i] & y[i]. len(y) >= len(x).
+func popcountAnd(x, y []uint64) int {
+	y = y[:len(x)]
+	var c0, c1, c2, c3 int
+	i := 0
+	for ; i+4 <= len(x); i += 4 {
+		a, b := x[i:i+4:i+4], y[i:i+4:i+4]
+		c0 += bits.OnesCount64(a[0] & b[0])
+		c1 += bits.OnesCount64(a[1] & b[1])
+		c2 += bits.OnesCount64(a[2] & b[2])
+		c3 += bits.OnesCount64(a[3] & b[3])
+	}
+	for ; i < len(x); i++ {
+		c0 += bits.OnesCount64(x[i] & y[i])
+	}
+	return c0 + c1 + c2 + c3
+}
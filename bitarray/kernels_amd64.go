@@ -0,0 +1,15 @@
+//go:build amd64 && !purego
+
+package bitarray
+
+//go:noescape
+func andWordsSSE2(dst, src []uint64)
+
+//go:noescape
+func orWordsSSE2(dst, src []uint64)
+
+// andWords выполняет dst[i] &= src[i]. len(src) >= len(dst).
+func andWords(dst, src []uint64) { andWordsSSE2(dst, src[:len(dst)]) }
+
+// orWords выполняет dst[i] |= src[i]. len(src) >= len(dst).
+func orWords(dst, src []uint64) { orWordsSSE2(dst, src[:len(dst)]) }
@@ -0,0 +1,81 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func andWordsSSE2(dst, src []uint64)
+// dst[i] &= src[i] для i < len(dst), по 4 слова за итерацию.
+TEXT ·andWordsSSE2(SB), NOSPLIT, $0-48
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ src_base+24(FP), SI
+	MOVQ CX, BX
+	SHRQ $2, BX
+	JZ   andTail
+
+andLoop:
+	MOVOU (DI), X0
+	MOVOU 16(DI), X1
+	MOVOU (SI), X2
+	MOVOU 16(SI), X3
+	PAND  X2, X0
+	PAND  X3, X1
+	MOVOU X0, (DI)
+	MOVOU X1, 16(DI)
+	ADDQ  $32, DI
+	ADDQ  $32, SI
+	DECQ  BX
+	JNZ   andLoop
+
+andTail:
+	ANDQ $3, CX
+	JZ   andDone
+
+andTailLoop:
+	MOVQ (SI), AX
+	ANDQ AX, (DI)
+	ADDQ $8, DI
+	ADDQ $8, SI
+	DECQ CX
+	JNZ  andTailLoop
+
+andDone:
+	RET
+
+// func orWordsSSE2(dst, src []uint64)
+// dst[i] |= src[i] для i < len(dst), по 4 слова за итерацию.
+TEXT ·orWordsSSE2(SB), NOSPLIT, $0-48
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ src_base+24(FP), SI
+	MOVQ CX, BX
+	SHRQ $2, BX
+	JZ   orTail
+
+orLoop:
+	MOVOU (DI), X0
+	MOVOU 16(DI), X1
+	MOVOU (SI), X2
+	MOVOU 16(SI), X3
+	POR   X2, X0
+	POR   X3, X1
+	MOVOU X0, (DI)
+	MOVOU X1, 16(DI)
+	ADDQ  $32, DI
+	ADDQ  $32, SI
+	DECQ  BX
+	JNZ   orLoop
+
+orTail:
+	ANDQ $3, CX
+	JZ   orDone
+
+orTailLoop:
+	MOVQ (SI), AX
+	ORQ  AX, (DI)
+	ADDQ $8, DI
+	ADDQ $8, SI
+	DECQ CX
+	JNZ  orTailLoop
+
+orDone:
+	RET
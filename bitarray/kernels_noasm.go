@@ -0,0 +1,9 @@
+//go:build !amd64 || purego
+
+package bitarray
+
+// andWords выполняет dst[i] &= src[i]. len(src) >= len(dst).
+func andWords(dst, src []uint64) { andWordsGeneric(dst, src) }
+
+// orWords выполняет dst[i] |= src[i]. len(src) >= len(dst).
+func orWords(dst, src []uint64) { orWordsGeneric(dst, src) }
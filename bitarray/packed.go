@@ -0,0 +1,151 @@
+package bitarray
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrValueTooLarge возвращается при записи значения, не помещающегося в ширину элемента.
+var ErrValueTooLarge = errors.New("bitarray: value too large for width")
+
+// PackedArray — массив целых чисел фиксированной ширины от 1 до 64 бит,
+// плотно упакованных в хранилище BitArray. Элемент может пересекать границу слов.
+type PackedArray struct {
+	bits  *BitArray
+	width uint   // ширина элемента в битах
+	len   uint   // количество элементов
+	max   uint64 // максимальное значение элемента
+}
+
+// NewPackedArray создает массив из n элементов шириной width бит.
+// Паникует, если n*width больше math.MaxUint-63.
+func NewPackedArray(n, width uint) *PackedArray {
+	if width == 0 || width > wordSize {
+		panic("`width` must be between 1 and 64")
+	}
+	if n > maxBitCnt/width {
+		panic(fmt.Errorf("%w: %d elements of %d bits", ErrOutOfRange, n, width))
+	}
+	return &PackedArray{
+		bits:  NewBitArray(n * width),
+		width: width,
+		len:   n,
+		max:   ^uint64(0) >> (wordSize - width),
+	}
+}
+
+// Len возвращает количество элементов.
+func (p *PackedArray) Len() uint { return p.len }
+
+// Width возвращает ширину элемента в битах.
+func (p *PackedArray) Width() uint { return p.width }
+
+// Max возвращает максимальное значение элемента.
+func (p *PackedArray) Max() uint64 { return p.max }
+
+// Bits возвращает битовое хранилище массива, например для сериализации.
+func (p *PackedArray) Bits() *BitArray { return p.bits }
+
+func (p *PackedArray) checkIdx(i uint) error {
+	if i >= p.len {
+		return fmt.Errorf("%w: %d >= %d", ErrOutOfRange, i, p.len)
+	}
+	return nil
+}
+
+// get читает элемент без проверки индекса.
+func (p *PackedArray) get(i uint) uint64 {
+	pos := i * p.width
+	wordIdx, off := pos/wordSize, pos%wordSize
+
+	v := p.bits.data[wordIdx] >> off
+	if off+p.width > wordSize {
+		// элемент продолжается в следующем слове
+		v |= p.bits.data[wordIdx+1] << (wordSize - off)
+	}
+	return v & p.max
+}
+
+// set пишет элемент без проверки индекса и значения.
+func (p *PackedArray) set(i uint, v uint64) {
+	pos := i * p.width
+	wordIdx, off := pos/wordSize, pos%wordSize
+
+	data := p.bits.data
+	data[wordIdx] = data[wordIdx]&^(p.max<<off) | v<<off
+	if off+p.width > wordSize {
+		shift := wordSize - off
+		data[wordIdx+1] = data[wordIdx+1]&^(p.max>>shift) | v>>shift
+	}
+}
+
+// Get возвращает элемент. Паникует, если индекс за пределами массива.
+func (p *PackedArray) Get(i uint) uint64 {
+	if err := p.checkIdx(i); err != nil {
+		panic(err)
+	}
+	return p.get(i)
+}
+
+// Set записывает элемент. Паникует, если индекс за пределами массива
+// или значение не помещается в ширину элемента.
+func (p *PackedArray) Set(i uint, v uint64) {
+	if err := p.TrySet(i, v); err != nil {
+		panic(err)
+	}
+}
+
+// TrySet записывает элемент или возвращает ErrOutOfRange / ErrValueTooLarge.
+func (p *PackedArray) TrySet(i uint, v uint64) error {
+	if err := p.checkIdx(i); err != nil {
+		return err
+	}
+	if v > p.max {
+		return fmt.Errorf("%w: %d > %d", ErrValueTooLarge, v, p.max)
+	}
+	p.set(i, v)
+	return nil
+}
+
+// Inc увеличивает элемент на единицу, не превышая Max, и возвращает новое значение.
+func (p *PackedArray) Inc(i uint) uint64 {
+	v := p.Get(i)
+	if v < p.max {
+		v++
+		p.set(i, v)
+	}
+	return v
+}
+
+// Dec уменьшает элемент на единицу, не опускаясь ниже нуля, и возвращает новое значение.
+func (p *PackedArray) Dec(i uint) uint64 {
+	v := p.Get(i)
+	if v > 0 {
+		v--
+		p.set(i, v)
+	}
+	return v
+}
+
+// All возвращает итератор по парам (индекс, значение).
+func (p *PackedArray) All() iter.Seq2[uint, uint64] {
+	return func(yield func(uint, uint64) bool) {
+		for i := uint(0); i < p.len; i++ {
+			if !yield(i, p.get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Fill записывает v во все элементы.
+func (p *PackedArray) Fill(v uint64) error {
+	if v > p.max {
+		return fmt.Errorf("%w: %d > %d", ErrValueTooLarge, v, p.max)
+	}
+	for i := uint(0); i < p.len; i++ {
+		p.set(i, v)
+	}
+	return nil
+}
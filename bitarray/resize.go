@@ -0,0 +1,124 @@
+package bitarray
+
+import "fmt"
+
+// Resize меняет размер массива. При увеличении новые биты сброшены,
+// при уменьшении биты за новым размером отбрасываются.
+// Паникует, если bitCnt больше math.MaxUint-63.
+func (b *BitArray) Resize(bitCnt uint) {
+	if bitCnt > maxBitCnt {
+		panic(fmt.Errorf("%w: resize to %d", ErrOutOfRange, bitCnt))
+	}
+	words := wordsFor(bitCnt)
+	switch {
+	case words > uint(cap(b.data)):
+		// растем с запасом, чтобы побитовая запись не копировала массив каждый раз
+		data := make([]uint64, words, max(words, 2*uint(cap(b.data))))
+		copy(data, b.data)
+		b.data = data
+	case words > uint(len(b.data)):
+		// хвост емкости мог остаться от прежнего уменьшения — обнуляем его
+		old := len(b.data)
+		b.data = b.data[:words]
+		clear(b.data[old:])
+	default:
+		b.data = b.data[:words]
+	}
+
+	b.size = bitCnt
+	b.clearTail()
+}
+
+// Truncate уменьшает массив до bitCnt бит.
+// Возвращает ErrOutOfRange, если bitCnt больше текущего размера.
+func (b *BitArray) Truncate(bitCnt uint) error {
+	if bitCnt > b.size {
+		return fmt.Errorf("%w: truncate to %d > %d", ErrOutOfRange, bitCnt, b.size)
+	}
+	b.Resize(bitCnt)
+	return nil
+}
+
+// checkRange проверяет диапазон [from, to) и возвращает его фактический конец.
+// Растущий массив расширяется до to, если grow истинно. Иначе диапазон
+// обрезается по размеру массива: биты за его пределами и так читаются как false.
+func (b *BitArray) checkRange(from, to uint, grow bool) (uint, error) {
+	if from > to {
+		return 0, fmt.Errorf("%w: invalid range [%d, %d)", ErrOutOfRange, from, to)
+	}
+	if to <= b.size {
+		return to, nil
+	}
+
+	switch {
+	case !b.growable:
+		return 0, fmt.Errorf("%w: range [%d, %d) exceeds %d", ErrOutOfRange, from, to, b.size)
+	case to > maxBitCnt:
+		return 0, fmt.Errorf("%w: range [%d, %d) exceeds %d", ErrOutOfRange, from, to, uint(maxBitCnt))
+	case grow:
+		b.Resize(to)
+		return to, nil
+	default:
+		return max(from, b.size), nil
+	}
+}
+
+// rangeApply применяет op к словам диапазона [from, to) с маской затрагиваемых бит.
+func (b *BitArray) rangeApply(from, to uint, op func(w, mask uint64) uint64) {
+	if from == to {
+		return
+	}
+
+	first, last := from/wordSize, (to-1)/wordSize
+	firstMask := ^uint64(0) << (from % wordSize)
+	lastMask := ^uint64(0) >> (wordSize - 1 - (to-1)%wordSize)
+
+	if first == last {
+		b.data[first] = op(b.data[first], firstMask&lastMask)
+		return
+	}
+
+	b.data[first] = op(b.data[first], firstMask)
+	for i := first + 1; i < last; i++ {
+		b.data[i] = op(b.data[i], ^uint64(0))
+	}
+	b.data[last] = op(b.data[last], lastMask)
+}
+
+// SetRange устанавливает биты диапазона [from, to) в val.
+// Растущий массив, как и в TrySet, расширяется только при установке бит.
+func (b *BitArray) SetRange(from, to uint, val bool) error {
+	to, err := b.checkRange(from, to, val)
+	if err != nil {
+		return err
+	}
+	if val {
+		b.rangeApply(from, to, func(w, mask uint64) uint64 { return w | mask })
+	} else {
+		b.rangeApply(from, to, func(w, mask uint64) uint64 { return w &^ mask })
+	}
+	return nil
+}
+
+// FlipRange инвертирует биты диапазона [from, to).
+func (b *BitArray) FlipRange(from, to uint) error {
+	to, err := b.checkRange(from, to, true)
+	if err != nil {
+		return err
+	}
+	b.rangeApply(from, to, func(w, mask uint64) uint64 { return w ^ mask })
+	return nil
+}
+
+// ClearAll сбрасывает все биты.
+func (b *BitArray) ClearAll() {
+	clear(b.data)
+}
+
+// SetAll устанавливает все биты.
+func (b *BitArray) SetAll() {
+	for i := range b.data {
+		b.data[i] = ^uint64(0)
+	}
+	b.clearTail()
+}
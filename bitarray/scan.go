@@ -0,0 +1,177 @@
+package bitarray
+
+import (
+	"iter"
+	"math/bits"
+	"sort"
+)
+
+// Count возвращает количество установленных бит.
+func (b *BitArray) Count() uint {
+	return uint(popcount(b.data))
+}
+
+// NextSet возвращает индекс первого установленного бита, начиная с i.
+// Если такого бита нет, возвращает false.
+func (b *BitArray) NextSet(i uint) (uint, bool) {
+	if i >= b.size {
+		return 0, false
+	}
+
+	wordIdx := i / wordSize
+	// отбрасываем биты младше i в первом слове
+	w := b.data[wordIdx] >> (i % wordSize)
+	if w != 0 {
+		return i + uint(bits.TrailingZeros64(w)), true
+	}
+
+	for wordIdx++; wordIdx < uint(len(b.data)); wordIdx++ {
+		if w = b.data[wordIdx]; w != 0 {
+			return wordIdx*wordSize + uint(bits.TrailingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear возвращает индекс первого сброшенного бита, начиная с i.
+// Если такого бита нет, возвращает false.
+func (b *BitArray) NextClear(i uint) (uint, bool) {
+	if i >= b.size {
+		return 0, false
+	}
+
+	wordIdx := i / wordSize
+	w := ^b.data[wordIdx] >> (i % wordSize)
+	if w != 0 {
+		return clearIdx(i+uint(bits.TrailingZeros64(w)), b.size)
+	}
+
+	for wordIdx++; wordIdx < uint(len(b.data)); wordIdx++ {
+		if w = ^b.data[wordIdx]; w != 0 {
+			return clearIdx(wordIdx*wordSize+uint(bits.TrailingZeros64(w)), b.size)
+		}
+	}
+	return 0, false
+}
+
+// clearIdx отбрасывает сброшенный бит хвоста последнего слова, лежащий за size.
+func clearIdx(idx, size uint) (uint, bool) {
+	if idx >= size {
+		return 0, false
+	}
+	return idx, true
+}
+
+// PrevSet возвращает индекс последнего установленного бита не старше i.
+// Если такого бита нет, возвращает false.
+func (b *BitArray) PrevSet(i uint) (uint, bool) {
+	if b.size == 0 {
+		return 0, false
+	}
+	i = min(i, b.size-1)
+
+	wordIdx := int(i / wordSize)
+	// отбрасываем биты старше i в первом слове
+	w := b.data[wordIdx] << (wordSize - 1 - i%wordSize)
+	if w != 0 {
+		return i - uint(bits.LeadingZeros64(w)), true
+	}
+
+	for wordIdx--; wordIdx >= 0; wordIdx-- {
+		if w = b.data[wordIdx]; w != 0 {
+			return uint(wordIdx)*wordSize + wordSize - 1 - uint(bits.LeadingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// Ones возвращает итератор по индексам установленных бит по возрастанию.
+func (b *BitArray) Ones() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for wordIdx, w := range b.data {
+			for w != 0 {
+				idx := uint(wordIdx)*wordSize + uint(bits.TrailingZeros64(w))
+				if !yield(idx) {
+					return
+				}
+				w &= w - 1 // сбрасываем младший установленный бит
+			}
+		}
+	}
+}
+
+// Rank возвращает количество установленных бит в диапазоне [0, i).
+func (b *BitArray) Rank(i uint) uint {
+	i = min(i, b.size)
+	cnt := 0
+	for _, w := range b.data[:i/wordSize] {
+		cnt += bits.OnesCount64(w)
+	}
+	if rem := i % wordSize; rem != 0 {
+		cnt += bits.OnesCount64(b.data[i/wordSize] & (1<<rem - 1))
+	}
+	return uint(cnt)
+}
+
+// Select возвращает индекс k-го (с нуля) установленного бита.
+// Если установленных бит не больше k, возвращает false.
+func (b *BitArray) Select(k uint) (uint, bool) {
+	for wordIdx, w := range b.data {
+		cnt := uint(bits.OnesCount64(w))
+		if k < cnt {
+			return uint(wordIdx)*wordSize + selectInWord(w, k), true
+		}
+		k -= cnt
+	}
+	return 0, false
+}
+
+// selectInWord возвращает позицию k-го установленного бита в слове (k < popcount(w)).
+func selectInWord(w uint64, k uint) uint {
+	for ; k > 0; k-- {
+		w &= w - 1
+	}
+	return uint(bits.TrailingZeros64(w))
+}
+
+// RankIndex — предвычисленный индекс для Rank и Select за O(1) и O(log n).
+// Индекс строится по снимку массива и после изменения массива устаревает:
+// его нужно построить заново.
+type RankIndex struct {
+	ba     *BitArray
+	counts []uint // counts[i] — количество установленных бит в словах [0, i)
+}
+
+// NewRankIndex строит индекс по текущему содержимому массива.
+func (b *BitArray) NewRankIndex() *RankIndex {
+	counts := make([]uint, len(b.data)+1)
+	for i, w := range b.data {
+		counts[i+1] = counts[i] + uint(bits.OnesCount64(w))
+	}
+	return &RankIndex{ba: b, counts: counts}
+}
+
+// Rank возвращает количество установленных бит в диапазоне [0, i).
+func (r *RankIndex) Rank(i uint) uint {
+	i = min(i, r.ba.size)
+	cnt := r.counts[i/wordSize]
+	if rem := i % wordSize; rem != 0 {
+		cnt += uint(bits.OnesCount64(r.ba.data[i/wordSize] & (1<<rem - 1)))
+	}
+	return cnt
+}
+
+// Select возвращает индекс k-го (с нуля) установленного бита.
+func (r *RankIndex) Select(k uint) (uint, bool) {
+	if k >= r.counts[len(r.counts)-1] {
+		return 0, false
+	}
+	// первое слово, до конца которого набирается больше k бит
+	wordIdx := sort.Search(len(r.ba.data), func(i int) bool { return r.counts[i+1] > k })
+	return uint(wordIdx)*wordSize + selectInWord(r.ba.data[wordIdx], k-r.counts[wordIdx]), true
+}
+
+// Count возвращает количество установленных бит.
+func (r *RankIndex) Count() uint {
+	return r.counts[len(r.counts)-1]
+}
@@ -1,7 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
 
 	"github.com/xyersh/xuyacs/bitarray"
 )
@@ -19,4 +27,484 @@ func main() {
 	fmt.Printf("[2]: %v\n", ba.Get(2))
 	fmt.Printf("[3]: %v\n", ba.Get(3))
 	fmt.Printf("[4]: %v\n", ba.Get(4))
+
+	if _, err := ba.TryGet(ba.Len()); err != nil {
+		fmt.Printf("[%d]: %v\n", ba.Len(), err)
+	}
+
+	mask := bitarray.NewBitArray(64)
+	mask.Set(3, true)
+	sub, _ := mask.IsSubset(ba)
+	fmt.Printf("mask is subset: %t\n", sub)
+
+	if _, err := ba.And(bitarray.NewBitArray(65)); err != nil {
+		fmt.Printf("and: %v\n", err)
+	}
+
+	growDemo()
+	persistDemo(ba)
+	stressAtomic()
+	checkKernels()
+	checkPacked()
+	checkScan()
+	checkText()
+	benchBulk()
+}
+
+// growDemo проверяет, что растущий массив не теряет данные на крайних индексах
+// и не выделяет память ради сброса бита за пределами.
+func growDemo() {
+	g := bitarray.NewGrowableBitArray(128)
+	g.Set(3, true)
+
+	if err := g.TrySet(^uint(0), true); err == nil || g.Len() != 128 || g.Count() != 1 {
+		panic(fmt.Sprintf("grow: max index: err %v, len %d, count %d", err, g.Len(), g.Count()))
+	}
+	if err := g.TrySet(1<<20, false); err != nil || g.Len() != 128 {
+		panic(fmt.Sprintf("grow: clear past end: err %v, len %d", err, g.Len()))
+	}
+	if err := g.SetRange(0, ^uint(0), true); !errors.Is(err, bitarray.ErrOutOfRange) {
+		panic(fmt.Sprintf("grow: SetRange to max: %v", err))
+	}
+	if err := g.FlipRange(0, ^uint(0)); !errors.Is(err, bitarray.ErrOutOfRange) || g.Len() != 128 {
+		panic(fmt.Sprintf("grow: FlipRange to max: %v, len %d", err, g.Len()))
+	}
+	if err := g.SetRange(0, 1<<20, false); err != nil || g.Len() != 128 || g.Get(3) {
+		panic(fmt.Sprintf("grow: clear range past end: err %v, len %d", err, g.Len()))
+	}
+	g.Set(3, true)
+	g.Set(200, true)
+	fmt.Printf("growable: len %d, count %d\n", g.Len(), g.Count())
+}
+
+// persistDemo сохраняет массив в файл и открывает его без копирования слов.
+// Вместо os.ReadFile можно отобразить файл в память через syscall.Mmap.
+func persistDemo(ba *bitarray.BitArray) {
+	path := filepath.Join(os.TempDir(), "bitarray.bin")
+	defer os.Remove(path)
+
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	n, err := ba.WriteTo(f)
+	f.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	view, err := bitarray.View(data)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("persisted %d bytes, view equal: %t\n", n, view.Equal(ba))
+
+	data[len(data)-1] ^= 0xff
+	_, err = bitarray.View(data)
+	fmt.Printf("corrupted: %v\n", err)
+
+	// вид не должен расти в запасную емкость чужого среза
+	backing := []uint64{1, 0xdead, 0xbeef, 0xcafe}
+	fw, err := bitarray.FromWords(backing[:1], 64)
+	if err != nil {
+		panic(err)
+	}
+	fw.Resize(200)
+	fw.Set(130, true)
+	if backing[1] != 0xdead || backing[2] != 0xbeef || backing[3] != 0xcafe {
+		panic(fmt.Sprintf("FromWords: Resize overwrote caller memory: %x", backing))
+	}
+
+	// заголовок с огромным размером и без данных
+	huge := append([]byte(nil), data[:24]...)
+	for i := 8; i < 24; i++ {
+		huge[i] = 0xff
+	}
+	huge[16], huge[17], huge[18], huge[19] = 0, 0, 0, 0
+	if _, err := bitarray.View(huge); err == nil {
+		panic("View accepted oversized header")
+	}
+	huge[15] = 0 // 2^56-1 бит
+	var restored bitarray.BitArray
+	if err := restored.UnmarshalBinary(huge); err == nil {
+		panic("UnmarshalBinary accepted truncated payload")
+	} else {
+		fmt.Printf("oversized: %v\n", err)
+	}
+}
+
+// stressAtomic проверяет, что каждый бит достается ровно одной горутине.
+// Запускать с -race: go run -race ./bitarray/test
+func stressAtomic() {
+	const (
+		size    = 1 << 16
+		workers = 8
+	)
+
+	ab := bitarray.NewAtomicBitArray(size)
+	won := make([]int, workers)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := uint(0); i < size; i++ {
+				if !ab.TestAndSet(i) {
+					won[w]++
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range won {
+		total += n
+	}
+	if total != size || ab.Count() != size {
+		panic(fmt.Sprintf("atomic: bits won %d, count %d, want %d", total, ab.Count(), size))
+	}
+	fmt.Printf("atomic: bits won %d of %d, count %d\n", total, size, ab.Count())
+
+	for _, bad := range []func(){
+		func() { ab.Get(size) },
+		func() { bitarray.NewAtomicBitArray(math.MaxUint) },
+	} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, bitarray.ErrOutOfRange) {
+					panic(fmt.Sprintf("atomic: expected ErrOutOfRange, got %v", err))
+				}
+				fmt.Printf("atomic: %v\n", err)
+			}()
+			bad()
+		}()
+	}
+}
+
+// byteBits — прежняя побайтовая раскладка, для сравнения скорости.
+type byteBits struct {
+	data []byte
+	size uint
+}
+
+func (b *byteBits) set(i uint, v bool) {
+	if v {
+		b.data[i/8] |= 1 << (i % 8)
+	} else {
+		b.data[i/8] &^= 1 << (i % 8)
+	}
+}
+
+func (b *byteBits) get(i uint) bool { return b.data[i/8]&(1<<(i%8)) != 0 }
+
+// and — побитовое И, как его приходилось писать без массовых операций.
+func (b *byteBits) and(other *byteBits) {
+	for i := uint(0); i < b.size; i++ {
+		b.set(i, b.get(i) && other.get(i))
+	}
+}
+
+// randomArray возвращает массив со случайными битами.
+func randomArray(size uint, rnd *rand.Rand) *bitarray.BitArray {
+	ba := bitarray.NewBitArray(size)
+	for i := uint(0); i < size; i++ {
+		ba.Set(i, rnd.IntN(3) == 0)
+	}
+	return ba
+}
+
+// checkKernels сравнивает массовые операции с наивной побитовой реализацией.
+func checkKernels() {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, size := range []uint{0, 1, 63, 64, 65, 200, 255, 256, 257, 1000, 4099} {
+		x, y := randomArray(size, rnd), randomArray(size, rnd)
+
+		and, _ := x.And(y)
+		or, _ := x.Or(y)
+		andCnt, _ := x.AndCount(y)
+
+		var wantCount, wantAndCnt uint
+		for i := uint(0); i < size; i++ {
+			if x.Get(i) {
+				wantCount++
+			}
+			if and.Get(i) != (x.Get(i) && y.Get(i)) || or.Get(i) != (x.Get(i) || y.Get(i)) {
+				panic(fmt.Sprintf("kernel mismatch at size %d, bit %d", size, i))
+			}
+			if x.Get(i) && y.Get(i) {
+				wantAndCnt++
+			}
+		}
+		if x.Count() != wantCount || andCnt != wantAndCnt {
+			panic(fmt.Sprintf("popcount mismatch at size %d", size))
+		}
+	}
+	fmt.Println("kernels: match naive implementation")
+}
+
+// checkPacked сравнивает PackedArray со срезом []uint64 при разных ширинах,
+// включая элементы, пересекающие границу слов.
+func checkPacked() {
+	rnd := rand.New(rand.NewPCG(3, 4))
+	for _, width := range []uint{1, 3, 7, 13, 31, 32, 33, 63, 64} {
+		const n = 300
+		p := bitarray.NewPackedArray(n, width)
+		want := make([]uint64, n)
+		for range 4 * n {
+			i := rnd.UintN(n)
+			switch rnd.IntN(4) {
+			case 0:
+				want[i] = rnd.Uint64() & p.Max()
+				p.Set(i, want[i])
+			case 1:
+				// крайние значения проверяют насыщение
+				want[i] = []uint64{0, p.Max()}[rnd.IntN(2)]
+				p.Set(i, want[i])
+			case 2:
+				if want[i] < p.Max() {
+					want[i]++
+				}
+				if got := p.Inc(i); got != want[i] {
+					panic(fmt.Sprintf("packed width %d: Inc(%d) = %d, want %d", width, i, got, want[i]))
+				}
+			default:
+				if want[i] > 0 {
+					want[i]--
+				}
+				if got := p.Dec(i); got != want[i] {
+					panic(fmt.Sprintf("packed width %d: Dec(%d) = %d, want %d", width, i, got, want[i]))
+				}
+			}
+		}
+
+		for i := range uint(n) {
+			if got := p.Get(i); got != want[i] {
+				panic(fmt.Sprintf("packed width %d: Get(%d) = %d, want %d", width, i, got, want[i]))
+			}
+		}
+		seen := uint(0)
+		for i, v := range p.All() {
+			if i != seen || v != want[i] {
+				panic(fmt.Sprintf("packed width %d: All yielded (%d, %d)", width, i, v))
+			}
+			seen++
+		}
+		if seen != n {
+			panic(fmt.Sprintf("packed width %d: All yielded %d elements", width, seen))
+		}
+
+		if width < 64 {
+			if err := p.TrySet(0, p.Max()+1); !errors.Is(err, bitarray.ErrValueTooLarge) {
+				panic(fmt.Sprintf("packed width %d: oversized value accepted: %v", width, err))
+			}
+		}
+		if err := p.TrySet(n, 0); !errors.Is(err, bitarray.ErrOutOfRange) {
+			panic(fmt.Sprintf("packed width %d: out of range index accepted: %v", width, err))
+		}
+	}
+
+	// n*width не должно переполняться
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, bitarray.ErrOutOfRange) {
+				panic(fmt.Sprintf("packed: huge array: %v", err))
+			}
+			fmt.Printf("packed huge: %v\n", err)
+		}()
+		bitarray.NewPackedArray(math.MaxUint/2, 4)
+	}()
+	fmt.Println("packed: matches reference slice")
+}
+
+// checkScan сравнивает поиск, Rank и Select с перебором по []bool.
+// Плотность меняется от пустого до полностью заполненного массива,
+// чтобы затронуть хвост последнего слова.
+func checkScan() {
+	rnd := rand.New(rand.NewPCG(5, 6))
+	for _, size := range []uint{0, 1, 63, 64, 65, 127, 128, 129, 300} {
+		for _, density := range []int{0, 1, 50, 99, 100} {
+			ba := bitarray.NewBitArray(size)
+			ref := make([]bool, size)
+			var ones []uint
+			for i := range size {
+				ref[i] = rnd.IntN(100) < density
+				ba.Set(i, ref[i])
+				if ref[i] {
+					ones = append(ones, i)
+				}
+			}
+			ri := ba.NewRankIndex()
+			fail := func(what string, i uint, got, want any) {
+				panic(fmt.Sprintf("scan size %d density %d: %s(%d) = %v, want %v", size, density, what, i, got, want))
+			}
+
+			// перебираем и индексы за концом массива
+			for i := range size + 2 {
+				wantNext, wantNextOK := uint(0), false
+				for j := i; j < size; j++ {
+					if ref[j] {
+						wantNext, wantNextOK = j, true
+						break
+					}
+				}
+				if got, ok := ba.NextSet(i); got != wantNext || ok != wantNextOK {
+					fail("NextSet", i, got, wantNext)
+				}
+
+				wantClear, wantClearOK := uint(0), false
+				for j := i; j < size; j++ {
+					if !ref[j] {
+						wantClear, wantClearOK = j, true
+						break
+					}
+				}
+				if got, ok := ba.NextClear(i); got != wantClear || ok != wantClearOK {
+					fail("NextClear", i, got, wantClear)
+				}
+
+				wantPrev, wantPrevOK := uint(0), false
+				for j := min(i+1, size); j > 0; j-- {
+					if ref[j-1] {
+						wantPrev, wantPrevOK = j-1, true
+						break
+					}
+				}
+				if got, ok := ba.PrevSet(i); got != wantPrev || ok != wantPrevOK {
+					fail("PrevSet", i, got, wantPrev)
+				}
+
+				wantRank := uint(0)
+				for j := uint(0); j < min(i, size); j++ {
+					if ref[j] {
+						wantRank++
+					}
+				}
+				if got := ba.Rank(i); got != wantRank {
+					fail("Rank", i, got, wantRank)
+				}
+				if got := ri.Rank(i); got != wantRank {
+					fail("RankIndex.Rank", i, got, wantRank)
+				}
+			}
+
+			got := slices.Collect(ba.Ones())
+			if !slices.Equal(got, ones) {
+				panic(fmt.Sprintf("scan size %d density %d: Ones = %v, want %v", size, density, got, ones))
+			}
+			for k := range uint(len(ones)) + 2 {
+				want, wantOK := uint(0), k < uint(len(ones))
+				if wantOK {
+					want = ones[k]
+				}
+				if got, ok := ba.Select(k); got != want || ok != wantOK {
+					fail("Select", k, got, want)
+				}
+				if got, ok := ri.Select(k); got != want || ok != wantOK {
+					fail("RankIndex.Select", k, got, want)
+				}
+			}
+			if ri.Count() != uint(len(ones)) {
+				fail("RankIndex.Count", 0, ri.Count(), len(ones))
+			}
+		}
+	}
+	fmt.Println("scan: matches []bool reference")
+}
+
+// checkText проверяет обратимость текстовых форматов, в том числе для размеров,
+// не кратных 8, и отказ при установленных битах за пределами размера.
+func checkText() {
+	rnd := rand.New(rand.NewPCG(7, 8))
+	for _, size := range []uint{0, 1, 5, 8, 13, 63, 64, 65, 100} {
+		ba := randomArray(size, rnd)
+		if size > 0 {
+			ba.Set(size-1, true) // старший бит проверяет последний неполный байт
+		}
+
+		parsed, err := bitarray.Parse(ba.String())
+		if err != nil || !parsed.Equal(ba) {
+			panic(fmt.Sprintf("text size %d: Parse(%q) = %v, %v", size, ba.String(), parsed, err))
+		}
+
+		text, _ := ba.MarshalText()
+		var unmarshaled bitarray.BitArray
+		if err := unmarshaled.UnmarshalText(text); err != nil || !unmarshaled.Equal(ba) {
+			panic(fmt.Sprintf("text size %d: UnmarshalText(%q): %v", size, text, err))
+		}
+
+		fromHex, err := bitarray.ParseHex(ba.Hex(), size)
+		if err != nil || !fromHex.Equal(ba) {
+			panic(fmt.Sprintf("text size %d: ParseHex(%q): %v", size, ba.Hex(), err))
+		}
+
+		fromBase64, err := bitarray.ParseBase64(ba.Base64(), size)
+		if err != nil || !fromBase64.Equal(ba) {
+			panic(fmt.Sprintf("text size %d: ParseBase64(%q): %v", size, ba.Base64(), err))
+		}
+	}
+
+	// 0x1f — пять бит, а размер всего 4: лишний бит должен быть отвергнут
+	for _, bad := range []struct {
+		name  string
+		parse func() (*bitarray.BitArray, error)
+	}{
+		{"hex tail", func() (*bitarray.BitArray, error) { return bitarray.ParseHex("1f", 4) }},
+		{"base64 tail", func() (*bitarray.BitArray, error) { return bitarray.ParseBase64("Hw==", 4) }},
+		{"hex length", func() (*bitarray.BitArray, error) { return bitarray.ParseHex("0f00", 4) }},
+		{"hex digits", func() (*bitarray.BitArray, error) { return bitarray.ParseHex("zz", 4) }},
+		{"hex huge", func() (*bitarray.BitArray, error) { return bitarray.ParseHex("", math.MaxUint) }},
+		{"bit string", func() (*bitarray.BitArray, error) { return bitarray.Parse("10x1") }},
+	} {
+		if _, err := bad.parse(); !errors.Is(err, bitarray.ErrInvalidFormat) {
+			panic(fmt.Sprintf("text: %s accepted: %v", bad.name, err))
+		}
+	}
+	fmt.Println("text: formats round-trip")
+}
+
+func benchBulk() {
+	const size = 1 << 20
+
+	x, y := bitarray.NewBitArray(size), bitarray.NewBitArray(size)
+	bx := &byteBits{data: make([]byte, size/8), size: size}
+	by := &byteBits{data: make([]byte, size/8), size: size}
+	for i := uint(0); i < size; i += 3 {
+		x.Set(i, true)
+		bx.set(i, true)
+	}
+	for i := uint(0); i < size; i += 5 {
+		y.Set(i, true)
+		by.set(i, true)
+	}
+
+	bench := func(name string, fn func()) {
+		res := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fn()
+			}
+		})
+		fmt.Printf("%-22s %s\n", name+":", res)
+	}
+
+	bench("and, byte per-bit", func() { bx.and(by) })
+	bench("and, kernel", func() { x.AndInPlace(y) })
+	bench("or, kernel", func() { x.OrInPlace(y) })
+	bench("count, per-bit", func() {
+		cnt := 0
+		for i := uint(0); i < size; i++ {
+			if x.Get(i) {
+				cnt++
+			}
+		}
+	})
+	bench("count, kernel", func() { x.Count() })
+	bench("and+count, kernel", func() { x.AndCount(y) })
 }
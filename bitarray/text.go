@@ -0,0 +1,131 @@
+package bitarray
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// DefaultGroup — размер группы бит в String.
+const DefaultGroup = 8
+
+// String возвращает массив в виде строки бит группами по DefaultGroup: "01011000 1".
+// Символ i соответствует биту i.
+func (b *BitArray) String() string {
+	return b.Grouped(DefaultGroup, " ")
+}
+
+// Grouped возвращает массив в виде строки бит, разбитой на группы по group бит
+// через разделитель sep. При group <= 0 строка не разбивается.
+func (b *BitArray) Grouped(group int, sep string) string {
+	var sb strings.Builder
+	sb.Grow(int(b.size) + int(b.size)/max(group, 1)*len(sep))
+	for i := uint(0); i < b.size; i++ {
+		if group > 0 && i > 0 && i%uint(group) == 0 {
+			sb.WriteString(sep)
+		}
+		if b.data[i/wordSize]&(1<<(i%wordSize)) != 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// Parse разбирает строку бит вида "1011 0010". Пробелы и '_' игнорируются,
+// размер массива равен количеству цифр.
+func Parse(s string) (*BitArray, error) {
+	b := NewGrowableBitArray(0)
+	idx := uint(0)
+	for pos, ch := range s {
+		switch ch {
+		case '0', '1':
+			b.Set(idx, ch == '1')
+			idx++
+		case ' ', '_', '\t', '\n':
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidFormat, ch, pos)
+		}
+	}
+	b.Resize(idx) // завершающие нули тоже входят в размер
+	b.growable = false
+	return b, nil
+}
+
+// MarshalText реализует encoding.TextMarshaler: строка бит без группировки.
+func (b *BitArray) MarshalText() ([]byte, error) {
+	return []byte(b.Grouped(0, "")), nil
+}
+
+// UnmarshalText реализует encoding.TextUnmarshaler, принимает формат Parse.
+func (b *BitArray) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	b.data, b.size = parsed.data, parsed.size
+	return nil
+}
+
+// packedBytes возвращает биты массива в little-endian байтах: бит i — бит i%8 байта i/8.
+func (b *BitArray) packedBytes() []byte {
+	buf := make([]byte, 0, 8*len(b.data))
+	for _, w := range b.data {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf[:(b.size+7)/8]
+}
+
+// fromPackedBytes создает массив из bitCnt бит по байтам, полученным packedBytes.
+func fromPackedBytes(data []byte, bitCnt uint) (*BitArray, error) {
+	if bitCnt > maxBitCnt {
+		return nil, fmt.Errorf("%w: size %d too large", ErrInvalidFormat, bitCnt)
+	}
+	if uint(len(data)) != (bitCnt+7)/8 {
+		return nil, fmt.Errorf("%w: %d bytes for %d bits", ErrInvalidFormat, len(data), bitCnt)
+	}
+
+	b := NewBitArray(bitCnt)
+	padded := make([]byte, 8*len(b.data))
+	copy(padded, data)
+	for i := range b.data {
+		b.data[i] = binary.LittleEndian.Uint64(padded[8*i:])
+	}
+	if err := b.checkTail(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Hex возвращает биты массива в шестнадцатеричном виде (байты little-endian).
+// Размер в строку не входит, при разборе его нужно передать в ParseHex.
+func (b *BitArray) Hex() string {
+	return hex.EncodeToString(b.packedBytes())
+}
+
+// ParseHex разбирает строку, полученную Hex, в массив из bitCnt бит.
+func ParseHex(s string, bitCnt uint) (*BitArray, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return fromPackedBytes(data, bitCnt)
+}
+
+// Base64 возвращает биты массива в base64 (стандартный алфавит, байты little-endian).
+// Размер в строку не входит, при разборе его нужно передать в ParseBase64.
+func (b *BitArray) Base64() string {
+	return base64.StdEncoding.EncodeToString(b.packedBytes())
+}
+
+// ParseBase64 разбирает строку, полученную Base64, в массив из bitCnt бит.
+func ParseBase64(s string, bitCnt uint) (*BitArray, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return fromPackedBytes(data, bitCnt)
+}
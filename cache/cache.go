@@ -0,0 +1,6 @@
+package cache
+
+import "github.com/xyersh/xuyacs/cache/internal/core"
+
+// Cache — общий интерфейс реализаций кэша: Put, Get, GetOK, Size, Clear и All.
+type Cache[K comparable, V any] = core.Cache[K, V]
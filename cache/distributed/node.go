@@ -0,0 +1,314 @@
+// Package distributed реализует распределенный кэш поверх lru.CacheLRU:
+// ключи распределяются между узлами через кольцо консистентного хеширования,
+// узлы общаются по HTTP, тела запросов и ответов кодируются gob.
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xyersh/xuyacs/cache"
+	"github.com/xyersh/xuyacs/cache/internal/flight"
+	"github.com/xyersh/xuyacs/cache/lru"
+)
+
+const (
+	DefaultBasePath = "/_xuyacs/"
+	DefaultReplicas = 50
+	DefaultTimeout  = 5 * time.Second // таймаут запроса к пиру у клиента по умолчанию
+
+	maxRequestSize = 1 << 20 // предельный размер тела запроса пира в байтах
+)
+
+// ErrPeer возвращается, если пир ответил некорректно или недоступен.
+var ErrPeer = errors.New("distributed: peer request failed")
+
+// request — тело запроса к пиру.
+type request[K any] struct {
+	Key K
+}
+
+// response — тело успешного ответа пира.
+type response[V any] struct {
+	Value V
+}
+
+type config struct {
+	basePath string
+	replicas int
+	client   *http.Client
+}
+
+// Option настраивает Node.
+type Option func(*config)
+
+// WithBasePath задает префикс URL, по которому узлы обслуживают друг друга.
+func WithBasePath(path string) Option {
+	return func(c *config) { c.basePath = path }
+}
+
+// WithReplicas задает количество виртуальных узлов на пира в кольце.
+func WithReplicas(n int) Option {
+	return func(c *config) { c.replicas = n }
+}
+
+// WithHTTPClient задает HTTP-клиент для запросов к пирам.
+// По умолчанию используется клиент с таймаутом DefaultTimeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) { c.client = client }
+}
+
+// Node — узел распределенного кэша.
+// Каждый узел хранит свою часть ключей в локальном LRU и
+// обслуживает запросы других узлов через ServeHTTP.
+type Node[K comparable, V any] struct {
+	self   string // адрес этого узла в кольце, например "http://10.0.0.1:8080"
+	cfg    config
+	loader cache.LoaderFunc[K, V]
+
+	mu    sync.Mutex
+	local *lru.CacheLRU[K, V]
+
+	flight flight.Group[K, V]
+
+	peersMu sync.RWMutex
+	ring    *Ring
+}
+
+// NewNode создает узел с адресом self, локальным LRU заданной емкости и
+// загрузчиком, вызываемым на промахе у владельца ключа. loader может быть nil,
+// тогда промах возвращает cache.ErrNotFound.
+func NewNode[K comparable, V any](self string, capacity int, loader cache.LoaderFunc[K, V], opts ...Option) *Node[K, V] {
+	cfg := config{
+		basePath: DefaultBasePath,
+		replicas: DefaultReplicas,
+		client:   &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n := &Node[K, V]{
+		self:   strings.TrimSuffix(self, "/"),
+		cfg:    cfg,
+		loader: loader,
+		local:  lru.NewLRU[K, V](capacity),
+	}
+	n.SetPeers(n.self)
+	return n
+}
+
+// SetPeers заменяет набор пиров. Адрес самого узла добавляется автоматически.
+func (n *Node[K, V]) SetPeers(peers ...string) {
+	ring := NewRing(n.cfg.replicas)
+	ring.Add(n.self)
+	for _, peer := range peers {
+		if peer = strings.TrimSuffix(peer, "/"); peer != n.self {
+			ring.Add(peer)
+		}
+	}
+
+	n.peersMu.Lock()
+	n.ring = ring
+	n.peersMu.Unlock()
+}
+
+// Owner возвращает адрес пира, отвечающего за ключ.
+func (n *Node[K, V]) Owner(key K) string {
+	n.peersMu.RLock()
+	defer n.peersMu.RUnlock()
+	return n.ring.Get(keyString(key))
+}
+
+// Get возвращает значение по ключу.
+// Порядок поиска: локальный кэш, затем владелец ключа, затем локальный загрузчик.
+// Если владелец недоступен, значение загружается локально.
+// Одновременные промахи по одному ключу выполняют один запрос к владельцу
+// или один вызов загрузчика. Отмена ctx прерывает ожидание только этого вызова.
+func (n *Node[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if value, ok := n.lookup(key); ok {
+		return value, nil
+	}
+
+	owner := n.Owner(key)
+	if owner == n.self {
+		return n.loadOnce(ctx, key)
+	}
+
+	return n.flight.Do(ctx, key, func(ctx context.Context) (V, error) {
+		if value, ok := n.lookup(key); ok {
+			return value, nil
+		}
+
+		value, err := n.fetch(ctx, owner, key)
+		if err == nil {
+			n.populate(key, value)
+			return value, nil
+		}
+
+		// ответ владельца окончателен, до локальной загрузки доходим только при сбое связи
+		if !errors.Is(err, ErrPeer) {
+			return value, err
+		}
+		return n.load(ctx, key)
+	})
+}
+
+// ServeHTTP обслуживает запросы других узлов.
+// Узел отвечает только из своего кэша или загрузчика и не пересылает запрос дальше.
+func (n *Node[K, V]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, n.cfg.basePath) {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request[K]
+	body := http.MaxBytesReader(w, r.Body, maxRequestSize)
+	if err := gob.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	value, ok := n.lookup(req.Key)
+	if !ok {
+		var err error
+		if value, err = n.loadOnce(r.Context(), req.Key); err != nil {
+			// запрашивающий узел сам обернет сообщение загрузчика в cache.LoadError
+			var loadErr *cache.LoadError
+			switch {
+			case errors.Is(err, cache.ErrNotFound):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case errors.As(err, &loadErr):
+				http.Error(w, loadErr.Err.Error(), http.StatusInternalServerError)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(response[V]{Value: value}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-gob")
+	w.Write(buf.Bytes())
+}
+
+// lookup ищет ключ в локальном кэше.
+func (n *Node[K, V]) lookup(key K) (V, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.local.GetOK(key)
+}
+
+// populate кладет значение в локальный кэш.
+func (n *Node[K, V]) populate(key K, value V) {
+	n.mu.Lock()
+	n.local.Put(key, value)
+	n.mu.Unlock()
+}
+
+// loadOnce загружает ключ, которым владеет этот узел,
+// объединяя одновременные загрузки через flight.
+func (n *Node[K, V]) loadOnce(ctx context.Context, key K) (V, error) {
+	return n.flight.Do(ctx, key, func(ctx context.Context) (V, error) {
+		if value, ok := n.lookup(key); ok {
+			return value, nil
+		}
+		return n.load(ctx, key)
+	})
+}
+
+// load вызывает локальный загрузчик и кэширует результат.
+func (n *Node[K, V]) load(ctx context.Context, key K) (V, error) {
+	var zero V
+	if n.loader == nil {
+		return zero, cache.ErrNotFound
+	}
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	value, err := n.loader(ctx, key)
+	if err != nil {
+		return zero, &cache.LoadError{Key: key, Err: err}
+	}
+
+	n.populate(key, value)
+	return value, nil
+}
+
+// fetch запрашивает значение у пира.
+func (n *Node[K, V]) fetch(ctx context.Context, peer string, key K) (V, error) {
+	var zero V
+
+	var body bytes.Buffer
+	if err := gob.NewEncoder(&body).Encode(request[K]{Key: key}); err != nil {
+		return zero, err
+	}
+
+	target, err := url.JoinPath(peer, n.cfg.basePath)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrPeer, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, &body)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrPeer, err)
+	}
+	req.Header.Set("Content-Type", "application/x-gob")
+
+	resp, err := n.cfg.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		return zero, fmt.Errorf("%w: %v", ErrPeer, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var out response[V]
+		if err := gob.NewDecoder(resp.Body).Decode(&out); err != nil {
+			return zero, fmt.Errorf("%w: %v", ErrPeer, err)
+		}
+		return out.Value, nil
+	case http.StatusNotFound:
+		return zero, cache.ErrNotFound
+	case http.StatusInternalServerError:
+		msg, _ := io.ReadAll(resp.Body)
+		return zero, &cache.LoadError{Key: key, Err: errors.New(strings.TrimSpace(string(msg)))}
+	default:
+		return zero, fmt.Errorf("%w: %s: unexpected status %s", ErrPeer, peer, resp.Status)
+	}
+}
+
+// keyString возвращает текстовое представление ключа для кольца.
+// Представление должно совпадать на всех узлах, поэтому ключи-указатели не подходят.
+func keyString[K comparable](key K) string {
+	if s, ok := any(key).(string); ok {
+		return s
+	}
+	return fmt.Sprint(key)
+}
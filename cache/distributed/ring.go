@@ -0,0 +1,72 @@
+package distributed
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/spaolacci/murmur3"
+)
+
+// Ring — кольцо консистентного хеширования с виртуальными узлами.
+// Ring не потокобезопасен: синхронизация — на стороне вызывающего кода.
+type Ring struct {
+	replicas int               // количество виртуальных узлов на одного пира
+	hashes   []uint32          // отсортированные хеши виртуальных узлов
+	owners   map[uint32]string // хеш виртуального узла -> адрес пира
+}
+
+// NewRing создает кольцо с заданным количеством виртуальных узлов на пира.
+func NewRing(replicas int) *Ring {
+	if replicas <= 0 {
+		replicas = 1
+	}
+	return &Ring{
+		replicas: replicas,
+		owners:   make(map[uint32]string),
+	}
+}
+
+// Add добавляет пиров в кольцо.
+func (r *Ring) Add(peers ...string) {
+	for _, peer := range peers {
+		for i := 0; i < r.replicas; i++ {
+			h := murmur3.Sum32([]byte(strconv.Itoa(i) + "#" + peer))
+			if _, exists := r.owners[h]; exists {
+				continue // коллизия хешей: виртуальный узел уже занят
+			}
+			r.owners[h] = peer
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	slices.Sort(r.hashes)
+}
+
+// Remove удаляет пира и все его виртуальные узлы из кольца.
+func (r *Ring) Remove(peer string) {
+	r.hashes = slices.DeleteFunc(r.hashes, func(h uint32) bool {
+		if r.owners[h] == peer {
+			delete(r.owners, h)
+			return true
+		}
+		return false
+	})
+}
+
+// Get возвращает пира, отвечающего за ключ, или пустую строку, если кольцо пусто.
+func (r *Ring) Get(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+
+	h := murmur3.Sum32([]byte(key))
+
+	// первый виртуальный узел по часовой стрелке от хеша ключа
+	idx, _ := slices.BinarySearch(r.hashes, h)
+	if idx == len(r.hashes) {
+		idx = 0
+	}
+	return r.owners[r.hashes[idx]]
+}
+
+// Len возвращает количество виртуальных узлов в кольце.
+func (r *Ring) Len() int { return len(r.hashes) }
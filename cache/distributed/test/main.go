@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xyersh/xuyacs/cache/distributed"
+)
+
+func main() {
+	var (
+		nodes   []*distributed.Node[string, string]
+		servers []*httptest.Server
+		addrs   []string
+		loads   atomic.Int32
+	)
+
+	// поднимаем три узла; адрес известен только после старта сервера,
+	// поэтому узел создается после него, а обработчик ссылается на переменную
+	for i := 0; i < 3; i++ {
+		var node *distributed.Node[string, string]
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			node.ServeHTTP(w, r)
+		}))
+		servers = append(servers, srv)
+		addrs = append(addrs, srv.URL)
+
+		self := srv.URL
+		node = distributed.NewNode(self, 16, func(ctx context.Context, key string) (string, error) {
+			loads.Add(1)
+			if key == "broken" {
+				return "", errors.New("backend unavailable")
+			}
+			if strings.HasPrefix(key, "hot") {
+				time.Sleep(50 * time.Millisecond)
+				return strings.ToUpper(key), nil
+			}
+			fmt.Printf("  load %q on %s\n", key, self)
+			return strings.ToUpper(key), nil
+		})
+		nodes = append(nodes, node)
+	}
+	defer func() {
+		for _, srv := range servers {
+			srv.Close()
+		}
+	}()
+
+	for _, node := range nodes {
+		node.SetPeers(addrs...)
+	}
+
+	ctx := context.Background()
+	for _, key := range []string{"Sasha", "Masha", "Dasha", "Natasha"} {
+		for i, node := range nodes {
+			val, err := node.Get(ctx, key)
+			fmt.Printf("node %d: %s -> %s (owner %s) err: %v\n", i, key, val, node.Owner(key), err)
+		}
+	}
+
+	// сообщение загрузчика владельца оборачивается один раз
+	for _, node := range nodes {
+		if node.Owner("broken") != addrs[0] {
+			_, err := node.Get(ctx, "broken")
+			fmt.Printf("broken: %v\n", err)
+			if strings.Count(err.Error(), "loader failed") != 1 {
+				panic("distributed: loader error wrapped twice")
+			}
+			break
+		}
+	}
+
+	// одновременные промахи по горячему ключу со всех узлов — одна загрузка
+	loads.Store(0)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := nodes[i%len(nodes)].Get(ctx, "hot-key"); err != nil {
+				panic(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := loads.Load(); n != 1 {
+		panic(fmt.Sprintf("distributed: hot key loaded %d times", n))
+	}
+	fmt.Printf("hot key: 30 concurrent gets, %d load\n", loads.Load())
+
+	hungPeerDemo()
+	cancelDemo()
+	oversizeDemo(addrs[0])
+}
+
+// oversizeDemo проверяет, что узел не читает запросы пиров неограниченного размера.
+func oversizeDemo(addr string) {
+	// префикс gob: длина сообщения 2 МиБ (0xFD — три байта длины), затем само сообщение
+	body := io.MultiReader(bytes.NewReader([]byte{0xfd, 0x20, 0x00, 0x00}), strings.NewReader(strings.Repeat("x", 2<<20)))
+	resp, err := http.Post(addr+distributed.DefaultBasePath, "application/x-gob", body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		panic(fmt.Sprintf("distributed: oversized request got %s", resp.Status))
+	}
+	fmt.Printf("oversized request: %s\n", resp.Status)
+}
+
+// cancelDemo проверяет, что отмена первого вызова не обрывает загрузку
+// для остальных, ждущих тот же ключ.
+func cancelDemo() {
+	node := distributed.NewNode("http://self", 16, func(ctx context.Context, key string) (string, error) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return strings.ToUpper(key), nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := node.Get(ctx, "k")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // первый вызов уже запустил загрузку
+
+	second := make(chan error)
+	go func() {
+		val, err := node.Get(context.Background(), "k")
+		if err == nil && val != "K" {
+			err = fmt.Errorf("got %q", val)
+		}
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		panic(fmt.Sprintf("distributed: cancelled caller got %v", err))
+	}
+	if err := <-second; err != nil {
+		panic(fmt.Sprintf("distributed: waiter failed after another caller cancelled: %v", err))
+	}
+	fmt.Println("cancel: other waiter still got the value")
+}
+
+// hungPeerDemo показывает, что зависший владелец не блокирует Get дольше
+// таймаута клиента: значение загружается локально.
+func hungPeerDemo() {
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	node := distributed.NewNode("http://self", 16,
+		func(ctx context.Context, key string) (string, error) { return "local " + key, nil },
+		distributed.WithHTTPClient(&http.Client{Timeout: 100 * time.Millisecond}),
+	)
+	node.SetPeers(hung.URL)
+
+	key := "k"
+	for i := 0; node.Owner(key) != hung.URL; i++ {
+		key = fmt.Sprintf("k%d", i)
+	}
+
+	start := time.Now()
+	val, err := node.Get(context.Background(), key)
+	if err != nil || time.Since(start) > time.Second {
+		panic(fmt.Sprintf("distributed: hung peer: %q, %v after %v", val, err, time.Since(start)))
+	}
+	fmt.Printf("hung peer: %q after %v\n", val, time.Since(start).Round(10*time.Millisecond))
+}
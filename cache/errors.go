@@ -0,0 +1,28 @@
+// Package cache содержит общие для всех реализаций кэша определения:
+// модель ошибок и типы загрузчиков значений.
+package cache
+
+import "github.com/xyersh/xuyacs/cache/internal/core"
+
+var (
+	// ErrNotFound возвращается, если ключа в кэше нет.
+	ErrNotFound = core.ErrNotFound
+
+	// ErrExpired возвращается, если ключ в кэше был, но срок жизни значения истек.
+	ErrExpired = core.ErrExpired
+
+	// ErrTooLarge возвращается, если значение не может быть помещено в кэш
+	// из-за превышения допустимого размера (стоимости).
+	ErrTooLarge = core.ErrTooLarge
+
+	// ErrLoad — общий признак ошибки загрузчика, проверяется через errors.Is.
+	ErrLoad = core.ErrLoad
+)
+
+// LoaderFunc загружает значение по ключу при промахе кэша.
+type LoaderFunc[K comparable, V any] = core.LoaderFunc[K, V]
+
+// LoadError оборачивает ошибку загрузчика.
+// errors.Is(err, ErrLoad) истинно для любой LoadError,
+// errors.Is/As также видят исходную ошибку загрузчика через Unwrap.
+type LoadError = core.LoadError
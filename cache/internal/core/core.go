@@ -0,0 +1,61 @@
+// Package core содержит базовые определения пакета cache: модель ошибок,
+// загрузчики и интерфейс Cache. Они вынесены сюда, чтобы cache/lru не зависел
+// от cache, а cache мог использовать lru. Публично доступны через псевдонимы в cache.
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var (
+	ErrNotFound = errors.New("cache: key not found")
+	ErrExpired  = errors.New("cache: key expired")
+	ErrTooLarge = errors.New("cache: value too large")
+	ErrLoad     = errors.New("cache: loader failed")
+)
+
+// LoaderFunc загружает значение по ключу при промахе кэша.
+type LoaderFunc[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// LoadError оборачивает ошибку загрузчика.
+type LoadError struct {
+	Key any   // ключ, для которого вызывался загрузчик
+	Err error // исходная ошибка загрузчика
+}
+
+// Error реализует интерфейс error.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("cache: loader failed for key %v: %v", e.Key, e.Err)
+}
+
+// Unwrap возвращает исходную ошибку загрузчика.
+func (e *LoadError) Unwrap() error { return e.Err }
+
+// Is позволяет сопоставлять любую LoadError с ErrLoad.
+func (e *LoadError) Is(target error) bool { return target == ErrLoad }
+
+// Cache — общий интерфейс реализаций кэша.
+type Cache[K comparable, V any] interface {
+	// Добавляет значение в кэш по ключу. Если ключ уже существует, обновляет значение.
+	Put(key K, value V)
+
+	// Возвращает значение по ключу и nil-ошибку, если ключ существует.
+	// Если ключа нет, возвращает нулевое значение для V и error.
+	Get(key K) (V, error)
+
+	// Возвращает значение по ключу и признак его наличия.
+	// В отличие от Get, не создает ошибку на промахе.
+	GetOK(key K) (V, bool)
+
+	// Возвращает количество элементов в кэше
+	Size() int
+
+	// Чистит кэш
+	Clear()
+
+	// Возвращает итератор по элементам кэша
+	All() iter.Seq2[K, V]
+}
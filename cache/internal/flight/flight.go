@@ -0,0 +1,94 @@
+// Package flight объединяет одновременные вызовы с одним ключом в один,
+// как golang.org/x/sync/singleflight. Используется cache.Memoize и distributed.Node.
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errGoexit получают ожидающие, если fn завершилась через runtime.Goexit.
+var errGoexit = errors.New("flight: function exited without returning")
+
+// call — вызов, выполняющийся в данный момент.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+
+	panicked bool
+	panicVal any
+
+	waiters int // сколько вызывающих еще ждут результата, под Group.mu
+	cancel  context.CancelFunc
+}
+
+// Group объединяет вызовы по ключу. Нулевое значение готово к использованию.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// Do вызывает fn для ключа, если вызов с этим ключом еще не идет,
+// иначе дожидается уже идущего.
+//
+// fn выполняется в отдельной горутине с контекстом, который наследует значения
+// ctx первого вызывающего, но отменяется, только когда ушли все ожидающие:
+// отмена одного вызывающего возвращает ему ctx.Err и не мешает остальным.
+// Если fn паникует, паника повторяется у каждого дождавшегося результата.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func(context.Context) (V, error)) (V, error) {
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		if g.calls == nil {
+			g.calls = make(map[K]*call[V])
+		}
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call[V]{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.panicked {
+			panic(c.panicVal)
+		}
+		return c.value, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		if c.waiters--; c.waiters == 0 {
+			c.cancel()
+		}
+		g.mu.Unlock()
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (g *Group[K, V]) run(ctx context.Context, key K, c *call[V], fn func(context.Context) (V, error)) {
+	returned := false
+	defer func() {
+		if !returned {
+			// паника или runtime.Goexit: ключ все равно освобождается,
+			// иначе следующие вызовы ждали бы вечно
+			if c.panicVal = recover(); c.panicVal != nil {
+				c.panicked = true
+			} else {
+				c.err = errGoexit
+			}
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.cancel()
+		close(c.done)
+	}()
+
+	c.value, c.err = fn(ctx)
+	returned = true
+}
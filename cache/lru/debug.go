@@ -0,0 +1,11 @@
+//go:build xuyacsdebug
+
+package lru
+
+// check сверяет map кэша со списком после Put.
+// Прогон с вытеснением: go run -tags xuyacsdebug ./cache/test/debug
+func (c *CacheLRU[K, V]) check() {
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+}
@@ -1,33 +1,21 @@
 package lru
 
 import (
-	"errors"
+	"context"
 	"iter"
 
+	"github.com/xyersh/xuyacs/cache/internal/core"
 	"github.com/xyersh/xuyacs/list"
 )
 
-type Cache[K comparable, V any] interface {
-	// Добавляет значение в кэш по ключу. Если ключ уже существует, обновляет значение.
-	Put(key K, value V)
-
-	// Возвращает значение по ключу и nil-ошибку, если ключ существует.
-	// Если ключа нет, возвращает нулевое значение для V и error.
-	Get(key K) (V, error)
-
-	// Возвращает количество элементов в кэше
-	Size() int
-
-	// Чистит кэш
-	Clear()
-
-	// Возвращает итератор по элементам кэша
-	All() iter.Seq2[K, V]
-}
+// Cache оставлен для совместимости, интерфейс переехал в пакет cache.
+type Cache[K comparable, V any] = core.Cache[K, V]
 
 var (
-	_              Cache[string, int] = (*CacheLRU[string, int])(nil)
-	ErrKeyNodFound error              = errors.New("key not found")
+	_ Cache[string, int] = (*CacheLRU[string, int])(nil)
+
+	// Deprecated: используйте cache.ErrNotFound.
+	ErrKeyNodFound = core.ErrNotFound
 )
 
 type node[K comparable, V any] struct {
@@ -80,19 +68,51 @@ func (c *CacheLRU[K, V]) getNodeFromElement(element *list.Element[*node[K, V]])
 
 // Get реализует интерфейс Cache.
 func (c *CacheLRU[K, V]) Get(key K) (V, error) {
+	if value, ok := c.GetOK(key); ok {
+		return value, nil
+	}
+
+	//если ключа НЕТ - вернем nil + error
+	var zero V
+	return zero, core.ErrNotFound
+}
+
+// GetOK реализует интерфейс Cache.
+func (c *CacheLRU[K, V]) GetOK(key K) (V, bool) {
 	if link, ok := c.keyToElement[key]; ok {
 
 		//если ключ ЕСТЬ - переместим элемент в начало списка
 		c.linkedList.MoveToFront(link)
 
-		//если ключ ЕСТЬ - вернем значение + nil
-		return c.getNodeFromElement(link).value, nil
+		//если ключ ЕСТЬ - вернем значение + true
+		return c.getNodeFromElement(link).value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// GetOrLoad возвращает значение по ключу, а при промахе вызывает loader
+// и кладет полученное значение в кэш.
+// Ошибка загрузчика возвращается обернутой в *cache.LoadError,
+// отмена ctx до вызова загрузчика возвращается как есть.
+func (c *CacheLRU[K, V]) GetOrLoad(ctx context.Context, key K, loader core.LoaderFunc[K, V]) (V, error) {
+	if value, ok := c.GetOK(key); ok {
+		return value, nil
 	}
 
-	//если ключа НЕТ - вернем nil + error
 	var zero V
-	return zero, ErrKeyNodFound
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	value, err := loader(ctx, key)
+	if err != nil {
+		return zero, &core.LoadError{Key: key, Err: err}
+	}
 
+	c.Put(key, value)
+	return value, nil
 }
 
 // Put реализует интерфейс Cache
@@ -101,6 +121,7 @@ func (c *CacheLRU[K, V]) Put(key K, value V) {
 	if link, ok := c.keyToElement[key]; ok {
 		c.getNodeFromElement(link).value = value
 		c.linkedList.MoveToFront(link)
+		c.check()
 
 		return
 	}
@@ -130,6 +151,7 @@ func (c *CacheLRU[K, V]) Put(key K, value V) {
 
 	// добавляем элемент в мапу
 	c.keyToElement[key] = new_element
+	c.check()
 }
 
 // Size реализует интерфейс Cache
@@ -0,0 +1,5 @@
+//go:build !xuyacsdebug
+
+package lru
+
+func (c *CacheLRU[K, V]) check() {}
@@ -0,0 +1,32 @@
+package lru
+
+import (
+	"fmt"
+
+	"github.com/xyersh/xuyacs/list"
+)
+
+// Validate проверяет согласованность кэша: инварианты внутреннего списка,
+// соответствие map и списка и соблюдение емкости.
+// Возвращает ошибку, оборачивающую list.ErrInvalid, при первом найденном нарушении.
+func (c *CacheLRU[K, V]) Validate() error {
+	if err := c.linkedList.Validate(); err != nil {
+		return err
+	}
+
+	if len(c.keyToElement) != c.linkedList.Len() {
+		return fmt.Errorf("%w: lru map has %d keys, list has %d elements",
+			list.ErrInvalid, len(c.keyToElement), c.linkedList.Len())
+	}
+	if c.capacity > 0 && c.Size() > c.capacity {
+		return fmt.Errorf("%w: lru size %d exceeds capacity %d", list.ErrInvalid, c.Size(), c.capacity)
+	}
+
+	for e := range c.linkedList.Elements() {
+		key := c.getNodeFromElement(e).key
+		if c.keyToElement[key] != e {
+			return fmt.Errorf("%w: lru key %v points to a foreign element", list.ErrInvalid, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/xyersh/xuyacs/cache/internal/flight"
+	"github.com/xyersh/xuyacs/cache/lru"
+)
+
+// Result — запомненный результат вызова функции.
+// Хранится в LRU-кэше Memoize или в кэше, переданном в MemoizeWith.
+type Result[V any] struct {
+	Value   V
+	Err     error
+	Expires time.Time // нулевое значение — без срока жизни
+}
+
+// expired сообщает, истек ли срок жизни результата на момент now.
+func (r Result[V]) expired(now time.Time) bool {
+	return !r.Expires.IsZero() && now.After(r.Expires)
+}
+
+// LookupResult возвращает действующий результат из store.
+// Если ключа нет, возвращает ErrNotFound, если срок жизни результата истек — ErrExpired.
+func LookupResult[K comparable, V any](store Cache[K, Result[V]], key K) (Result[V], error) {
+	res, ok := store.GetOK(key)
+	switch {
+	case !ok:
+		return Result[V]{}, ErrNotFound
+	case res.expired(time.Now()):
+		return Result[V]{}, ErrExpired
+	}
+	return res, nil
+}
+
+// DefaultMemoizeCapacity — емкость LRU-кэша, который Memoize создает по умолчанию.
+const DefaultMemoizeCapacity = 1024
+
+type memoizeConfig struct {
+	ttl      time.Duration
+	errorTTL time.Duration
+	capacity int
+}
+
+// MemoizeOption настраивает Memoize.
+type MemoizeOption func(*memoizeConfig)
+
+// WithTTL задает срок жизни успешных результатов. По умолчанию результаты не устаревают.
+func WithTTL(ttl time.Duration) MemoizeOption {
+	return func(c *memoizeConfig) { c.ttl = ttl }
+}
+
+// WithErrorTTL включает кэширование ошибок на заданный срок.
+// По умолчанию ошибки не кэшируются и следующий вызов повторяет fn.
+func WithErrorTTL(ttl time.Duration) MemoizeOption {
+	return func(c *memoizeConfig) { c.errorTTL = ttl }
+}
+
+// WithCapacity задает емкость LRU-кэша, создаваемого Memoize.
+// По умолчанию DefaultMemoizeCapacity. MemoizeWith эту опцию игнорирует.
+func WithCapacity(n int) MemoizeOption {
+	return func(c *memoizeConfig) { c.capacity = max(n, 1) }
+}
+
+func newMemoizeConfig(opts []MemoizeOption) memoizeConfig {
+	cfg := memoizeConfig{capacity: DefaultMemoizeCapacity}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Memoize возвращает функцию с той же сигнатурой, что и fn, запоминающую результаты
+// в LRU-кэше емкостью WithCapacity. Одновременные вызовы с одним ключом дожидаются
+// единственного вызова fn. Если fn паникует, паника повторяется у всех вызовов,
+// дождавшихся ее результата, а следующий вызов снова выполнит fn.
+func Memoize[K comparable, V any](fn func(K) (V, error), opts ...MemoizeOption) func(K) (V, error) {
+	cfg := newMemoizeConfig(opts)
+	return memoize(lru.NewLRU[K, Result[V]](cfg.capacity), fn, cfg)
+}
+
+// MemoizeWith работает как Memoize, но хранит результаты в store.
+// Доступ к store MemoizeWith синхронизирует сам.
+func MemoizeWith[K comparable, V any](store Cache[K, Result[V]], fn func(K) (V, error), opts ...MemoizeOption) func(K) (V, error) {
+	return memoize(store, fn, newMemoizeConfig(opts))
+}
+
+func memoize[K comparable, V any](store Cache[K, Result[V]], fn func(K) (V, error), cfg memoizeConfig) func(K) (V, error) {
+	var (
+		mu    sync.Mutex
+		calls flight.Group[K, V]
+	)
+
+	// lookup возвращает действующий результат из store
+	lookup := func(key K) (Result[V], bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		res, err := LookupResult(store, key)
+		return res, err == nil
+	}
+
+	return func(key K) (V, error) {
+		if res, ok := lookup(key); ok {
+			return res.Value, res.Err
+		}
+
+		// fn не принимает контекст, поэтому ожидание не прерывается
+		return calls.Do(context.Background(), key, func(context.Context) (V, error) {
+			// пока ждали, результат мог положить предыдущий вызов
+			if res, ok := lookup(key); ok {
+				return res.Value, res.Err
+			}
+
+			value, err := fn(key)
+			if ttl := cfg.ttl; err == nil || cfg.errorTTL > 0 {
+				if err != nil {
+					ttl = cfg.errorTTL
+				}
+				res := Result[V]{Value: value, Err: err}
+				if ttl > 0 {
+					res.Expires = time.Now().Add(ttl)
+				}
+				mu.Lock()
+				store.Put(key, res)
+				mu.Unlock()
+			}
+			return value, err
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/xyersh/xuyacs/bloom_filter"
+)
+
+const (
+	sketchDepth   = 4  // количество строк count-min sketch
+	sketchMaxFreq = 15 // счетчики насыщаются на 4-битном максимуме
+)
+
+// cmSketch — count-min sketch с насыщающимися счетчиками.
+// Оценивает частоту обращений к ключу сверху.
+type cmSketch struct {
+	rows [sketchDepth][]uint8
+	mask uint64
+}
+
+// newCMSketch создает скетч. numCounters должно быть положительным.
+func newCMSketch(numCounters int) *cmSketch {
+	// ширина строки округляется вверх до степени двойки, чтобы брать индекс маской
+	width := uint64(1) << bits.Len64(uint64(numCounters-1))
+
+	s := &cmSketch{mask: width - 1}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// index возвращает индекс счетчика в строке i (двойное хеширование, как в фильтре Блума).
+func (s *cmSketch) index(h uint64, i int) uint64 {
+	h1, h2 := h&0xffffffff, h>>32
+	return (h1 + uint64(i)*h2) & s.mask
+}
+
+func (s *cmSketch) Increment(h uint64) {
+	for i := range s.rows {
+		if idx := s.index(h, i); s.rows[i][idx] < sketchMaxFreq {
+			s.rows[i][idx]++
+		}
+	}
+}
+
+func (s *cmSketch) Estimate(h uint64) int {
+	minFreq := uint8(sketchMaxFreq)
+	for i := range s.rows {
+		minFreq = min(minFreq, s.rows[i][s.index(h, i)])
+	}
+	return int(minFreq)
+}
+
+// Reset делит все счетчики пополам, чтобы старая популярность со временем забывалась.
+func (s *cmSketch) Reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+}
+
+// tinyLFU — политика допуска: doorkeeper на фильтре Блума отсекает
+// ключи, встреченные один раз, а count-min sketch считает остальные.
+type tinyLFU struct {
+	sketch      *cmSketch
+	doorkeeper  *bloom_filter.BloomFilter
+	numCounters int
+	increments  int // количество учтенных обращений с последнего сброса
+	resetAt     int
+}
+
+func newTinyLFU(numCounters int) *tinyLFU {
+	numCounters = max(numCounters, 1)
+	return &tinyLFU{
+		sketch:      newCMSketch(numCounters),
+		doorkeeper:  bloom_filter.NewBloomFilter(numCounters, 0.01),
+		numCounters: numCounters,
+		resetAt:     numCounters * 10,
+	}
+}
+
+// Increment учитывает обращение к ключу с хешем h.
+func (t *tinyLFU) Increment(h uint64) {
+	key := binary.LittleEndian.AppendUint64(nil, h)
+	if t.doorkeeper.Test(key) {
+		t.sketch.Increment(h)
+	} else {
+		t.doorkeeper.Add(key)
+	}
+
+	if t.increments++; t.increments >= t.resetAt {
+		t.reset()
+	}
+}
+
+// Estimate возвращает оценку частоты обращений к ключу с хешем h.
+func (t *tinyLFU) Estimate(h uint64) int {
+	freq := t.sketch.Estimate(h)
+	if t.doorkeeper.Test(binary.LittleEndian.AppendUint64(nil, h)) {
+		freq++
+	}
+	return freq
+}
+
+func (t *tinyLFU) reset() {
+	t.increments = 0
+	t.sketch.Reset()
+	t.doorkeeper = bloom_filter.NewBloomFilter(t.numCounters, 0.01)
+}
+
+// Clear полностью сбрасывает статистику.
+func (t *tinyLFU) Clear() {
+	*t = *newTinyLFU(t.numCounters)
+}
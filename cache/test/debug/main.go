@@ -0,0 +1,45 @@
+//go:build xuyacsdebug
+
+// Прогон LRU-кэша в отладочной сборке: после каждого Put проверяется
+// согласованность map и списка, а список проверяет свои инварианты.
+//
+//	go run -tags xuyacsdebug ./cache/test/debug
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/xyersh/xuyacs/cache/lru"
+	"github.com/xyersh/xuyacs/list"
+)
+
+func main() {
+	rnd := rand.New(rand.NewPCG(3, 4))
+
+	c := lru.NewLRU[int, int](64)
+	for i := 0; i < 10000; i++ {
+		k := rnd.IntN(256)
+		if rnd.IntN(2) == 0 {
+			c.Put(k, i) // при заполненном кэше вытесняет самый старый ключ
+		} else {
+			c.GetOK(k)
+		}
+	}
+	if c.Size() != 64 {
+		panic(fmt.Sprintf("lru: size %d, want 64", c.Size()))
+	}
+
+	// рассинхронизируем map и список в обход кэша: следующий Put должен это заметить
+	c.GetList().Remove(c.GetList().Back())
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, list.ErrInvalid) {
+			panic(fmt.Sprintf("lru: want ErrInvalid, got %v", err))
+		}
+		fmt.Printf("corrupted lru: %v\n", err)
+		fmt.Println("debug checks passed")
+	}()
+	c.Put(-1, 0)
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xyersh/xuyacs/cache"
+	"github.com/xyersh/xuyacs/cache/lru"
+)
+
+func main() {
+	c := cache.NewTinyLFU[string, int](1000, 10, 64)
+	defer c.Close()
+
+	// "горячие" ключи, к которым обращаются часто
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 10; i++ {
+			key := fmt.Sprintf("hot-%d", i)
+			if _, ok := c.GetOK(key); !ok {
+				c.Put(key, i)
+			}
+		}
+		c.Wait()
+	}
+
+	// поток одноразовых ключей
+	for i := 0; i < 50; i++ {
+		c.Put(fmt.Sprintf("once-%d", i), i)
+		c.Wait()
+	}
+
+	hot := 0
+	for key := range c.All() {
+		if key[:3] == "hot" {
+			hot++
+		}
+	}
+	fmt.Printf("size: %d   hot keys survived: %d\n", c.Size(), hot)
+
+	fmt.Printf("too large: %v\n", c.PutWithCost("big", 0, 100))
+	if err := c.PutWithCost("negative", 0, -5); !errors.Is(err, cache.ErrNegativeCost) {
+		panic(fmt.Sprintf("negative cost accepted: %v", err))
+	}
+
+	// вырожденные параметры приводятся к допустимым
+	tiny := cache.NewTinyLFU[string, int](0, 0, 0)
+	tiny.Put("a", 1)
+	tiny.Wait()
+	fmt.Printf("tiny cache size: %d\n", tiny.Size())
+	tiny.Close()
+
+	c.Clear()
+	fmt.Printf("size after clear: %d\n", c.Size())
+
+	stressTinyLFU()
+	loadDemo()
+	memoizeDemo()
+}
+
+// loadDemo показывает, как ошибки загрузчика GetOrLoad различаются через errors.Is/As.
+func loadDemo() {
+	c := lru.NewLRU[string, int](10)
+	errDown := errors.New("backend down")
+
+	v, err := c.GetOrLoad(context.Background(), "a", func(context.Context, string) (int, error) {
+		return 1, nil
+	})
+	if v != 1 || err != nil {
+		panic(fmt.Sprintf("GetOrLoad: got %d, %v", v, err))
+	}
+	if v, ok := c.GetOK("a"); !ok || v != 1 {
+		panic("GetOrLoad: loaded value not stored")
+	}
+
+	_, err = c.GetOrLoad(context.Background(), "b", func(context.Context, string) (int, error) {
+		return 0, errDown
+	})
+	var loadErr *cache.LoadError
+	if !errors.Is(err, cache.ErrLoad) || !errors.Is(err, errDown) || !errors.As(err, &loadErr) {
+		panic(fmt.Sprintf("GetOrLoad: unexpected error %v", err))
+	}
+	if loadErr.Key != "b" || errors.Unwrap(err) != errDown {
+		panic(fmt.Sprintf("GetOrLoad: LoadError %+v", loadErr))
+	}
+	if _, ok := c.GetOK("b"); ok {
+		panic("GetOrLoad: failed load was cached")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetOrLoad(ctx, "c", func(context.Context, string) (int, error) {
+		panic("loader called with canceled context")
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, cache.ErrLoad) {
+		panic(fmt.Sprintf("GetOrLoad: canceled context gave %v", err))
+	}
+	fmt.Printf("canceled load: %v\n", err)
+	fmt.Printf("loader error: %v\n", loadErr)
+}
+
+// stressTinyLFU обновляет и читает одни и те же ключи из нескольких горутин.
+// Значение-массив позволяет заметить разорванное чтение.
+// Запускать с -race: go run -race ./cache/test
+func stressTinyLFU() {
+	c := cache.NewTinyLFU[int, [4]int](1000, 100, 1024)
+	defer c.Close()
+
+	const (
+		writers = 4
+		readers = 4
+		ops     = 20000
+	)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				c.Put(i%8, [4]int{i, i, i, i})
+			}
+		}()
+	}
+	hits := make([]int, readers)
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				v, ok := c.GetOK(i % 8)
+				if !ok {
+					continue
+				}
+				if v[0] != v[1] || v[1] != v[2] || v[2] != v[3] {
+					panic(fmt.Sprintf("tinylfu: torn read %v", v))
+				}
+				hits[r]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range hits {
+		total += n
+	}
+	fmt.Printf("tinylfu stress: %d consistent hits\n", total)
+}
+
+func memoizeDemo() {
+	calls := 0
+	square := cache.MemoizeWith(lru.NewLRU[int, cache.Result[int]](100), func(n int) (int, error) {
+		calls++
+		time.Sleep(10 * time.Millisecond)
+		return n * n, nil
+	}, cache.WithTTL(time.Minute))
+
+	// одновременные вызовы с одним ключом выполняют функцию один раз
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			square(7)
+		}()
+	}
+	wg.Wait()
+
+	v, err := square(7)
+	fmt.Printf("square(7) = %d, err: %v, calls: %d\n", v, err, calls)
+
+	// по умолчанию результаты хранятся в LRU ограниченной емкости
+	computed := make(map[int]int)
+	double := cache.Memoize(func(n int) (int, error) {
+		computed[n]++
+		return 2 * n, nil
+	}, cache.WithCapacity(2))
+	for _, n := range []int{1, 2, 1, 3, 1, 2} {
+		double(n)
+	}
+	// 1 остается свежим благодаря обращениям, 2 вытесняется ключом 3
+	if computed[1] != 1 || computed[2] != 2 || computed[3] != 1 {
+		panic(fmt.Sprintf("memoize: unexpected recomputations %v", computed))
+	}
+	fmt.Printf("bounded memoize: computed %v\n", computed)
+
+	// устаревший результат отличается от отсутствующего
+	store := lru.NewLRU[int, cache.Result[int]](10)
+	short := cache.MemoizeWith(store, func(n int) (int, error) { return n, nil }, cache.WithTTL(10*time.Millisecond))
+	if _, err := cache.LookupResult(store, 5); !errors.Is(err, cache.ErrNotFound) {
+		panic(fmt.Sprintf("memoize: lookup of missing key: %v", err))
+	}
+	short(5)
+	time.Sleep(20 * time.Millisecond)
+	if _, err := cache.LookupResult(store, 5); !errors.Is(err, cache.ErrExpired) {
+		panic(fmt.Sprintf("memoize: lookup of stale key: %v", err))
+	}
+	fmt.Printf("stale result: %v\n", cache.ErrExpired)
+
+	// паника в функции не оставляет ключ заблокированным
+	panics := true
+	flaky := cache.Memoize(func(n int) (int, error) {
+		if panics {
+			panics = false
+			panic("boom")
+		}
+		return n, nil
+	})
+	func() {
+		defer func() { fmt.Printf("flaky(1) panicked: %v\n", recover()) }()
+		flaky(1)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		v, err = flaky(1)
+		close(done)
+	}()
+	select {
+	case <-done:
+		fmt.Printf("flaky(1) = %d, err: %v\n", v, err)
+	case <-time.After(time.Second):
+		panic("memoize: key stuck after panic")
+	}
+}
@@ -0,0 +1,291 @@
+package cache
+
+import (
+	"errors"
+	"hash/maphash"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+const evictionSampleSize = 5 // сколько записей сравнивается при выборе жертвы
+
+var (
+	_ Cache[string, int] = (*CacheTinyLFU[string, int])(nil)
+
+	// ErrBufferFull возвращается, если буфер записи переполнен и запись отброшена.
+	ErrBufferFull = errors.New("cache: write buffer full")
+
+	// ErrClosed возвращается при записи в закрытый кэш.
+	ErrClosed = errors.New("cache: closed")
+
+	// ErrNegativeCost возвращается при записи с отрицательной стоимостью.
+	ErrNegativeCost = errors.New("cache: negative cost")
+)
+
+type entry[V any] struct {
+	value V
+	cost  int64
+	hash  uint64
+}
+
+// writeOp — операция в буфере записи.
+// Если done не nil, это барьер: обработчик закрывает done, когда дойдет до него.
+type writeOp[K comparable, V any] struct {
+	key   K
+	value V
+	cost  int64
+	hash  uint64
+	clear bool
+	done  chan struct{}
+}
+
+// CacheTinyLFU — кэш с политикой допуска TinyLFU и сэмплированным LFU-вытеснением
+// (по мотивам Ristretto). Новый ключ попадает в кэш, только если он популярнее
+// вытесняемых записей, поэтому поток одноразовых ключей не вымывает полезные.
+//
+// Записи применяются асинхронно фоновой горутиной: значение может появиться в Get
+// не сразу после Put. Wait дожидается применения всех ранее сделанных записей.
+type CacheTinyLFU[K comparable, V any] struct {
+	seed    maphash.Seed
+	maxCost int64
+
+	mu      sync.RWMutex
+	entries map[K]*entry[V]
+
+	// состояние ниже принадлежит фоновой горутине
+	usedCost int64
+	policy   *tinyLFU
+
+	setBuf chan writeOp[K, V]
+	getBuf chan uint64
+	stop   chan struct{}
+	closed atomic.Bool
+	wg     sync.WaitGroup
+}
+
+// NewTinyLFU создает кэш.
+// numCounters - количество счетчиков частоты (рекомендуется ~10x от ожидаемого числа ключей)
+// maxCost - суммарная допустимая стоимость записей
+// bufferSize - размер буфера асинхронных записей
+// Значения меньше 1 заменяются на 1.
+func NewTinyLFU[K comparable, V any](numCounters int, maxCost int64, bufferSize int) *CacheTinyLFU[K, V] {
+	bufferSize = max(bufferSize, 1)
+	c := &CacheTinyLFU[K, V]{
+		seed:    maphash.MakeSeed(),
+		maxCost: max(maxCost, 1),
+		entries: make(map[K]*entry[V]),
+		policy:  newTinyLFU(numCounters),
+		setBuf:  make(chan writeOp[K, V], bufferSize),
+		getBuf:  make(chan uint64, bufferSize),
+		stop:    make(chan struct{}),
+	}
+
+	c.wg.Add(1)
+	go c.process()
+	return c
+}
+
+// Put реализует интерфейс Cache. Стоимость записи равна 1.
+func (c *CacheTinyLFU[K, V]) Put(key K, value V) {
+	c.PutWithCost(key, value, 1)
+}
+
+// PutWithCost ставит запись в буфер. Запись может быть отклонена политикой допуска позже.
+// Возвращает ErrNegativeCost, если cost меньше нуля, ErrTooLarge, если cost больше maxCost,
+// ErrBufferFull, если буфер переполнен, и ErrClosed после Close.
+func (c *CacheTinyLFU[K, V]) PutWithCost(key K, value V, cost int64) error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	if cost < 0 {
+		return ErrNegativeCost
+	}
+	if cost > c.maxCost {
+		return ErrTooLarge
+	}
+
+	op := writeOp[K, V]{key: key, value: value, cost: cost, hash: maphash.Comparable(c.seed, key)}
+	select {
+	case c.setBuf <- op:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// Get реализует интерфейс Cache.
+func (c *CacheTinyLFU[K, V]) Get(key K) (V, error) {
+	if value, ok := c.GetOK(key); ok {
+		return value, nil
+	}
+	var zero V
+	return zero, ErrNotFound
+}
+
+// GetOK реализует интерфейс Cache.
+func (c *CacheTinyLFU[K, V]) GetOK(key K) (V, bool) {
+	h := maphash.Comparable(c.seed, key)
+
+	// обращение учитывается с потерями: при полном буфере оно просто отбрасывается
+	select {
+	case c.getBuf <- h:
+	default:
+	}
+
+	// значение копируется под блокировкой: apply обновляет запись на месте
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if e, ok := c.entries[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Size реализует интерфейс Cache.
+func (c *CacheTinyLFU[K, V]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Clear реализует интерфейс Cache.
+// Записи, сделанные до вызова Clear, тоже будут удалены.
+func (c *CacheTinyLFU[K, V]) Clear() {
+	c.barrier(true)
+}
+
+// Wait блокируется, пока не будут применены все записи, сделанные до вызова.
+func (c *CacheTinyLFU[K, V]) Wait() {
+	c.barrier(false)
+}
+
+// All реализует интерфейс Cache. Обходит снимок кэша в произвольном порядке.
+func (c *CacheTinyLFU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.mu.RLock()
+		snapshot := make(map[K]V, len(c.entries))
+		for k, e := range c.entries {
+			snapshot[k] = e.value
+		}
+		c.mu.RUnlock()
+
+		for k, v := range snapshot {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Close останавливает фоновую горутину. Необработанные записи отбрасываются.
+func (c *CacheTinyLFU[K, V]) Close() {
+	if c.closed.Swap(true) {
+		return
+	}
+	close(c.stop)
+	c.wg.Wait()
+}
+
+func (c *CacheTinyLFU[K, V]) barrier(clear bool) {
+	if c.closed.Load() {
+		return
+	}
+	done := make(chan struct{})
+	select {
+	case c.setBuf <- writeOp[K, V]{clear: clear, done: done}:
+	case <-c.stop:
+		return
+	}
+	select {
+	case <-done:
+	case <-c.stop:
+	}
+}
+
+// process — фоновая горутина, применяющая записи и учитывающая обращения.
+func (c *CacheTinyLFU[K, V]) process() {
+	defer c.wg.Done()
+	for {
+		select {
+		case op := <-c.setBuf:
+			c.apply(op)
+		case h := <-c.getBuf:
+			c.policy.Increment(h)
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *CacheTinyLFU[K, V]) apply(op writeOp[K, V]) {
+	if op.done != nil {
+		if op.clear {
+			c.mu.Lock()
+			clear(c.entries)
+			c.mu.Unlock()
+			c.usedCost = 0
+			c.policy.Clear()
+		}
+		close(op.done)
+		return
+	}
+
+	c.policy.Increment(op.hash)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// обновление существующего ключа допускается всегда
+	if e, ok := c.entries[op.key]; ok {
+		c.usedCost += op.cost - e.cost
+		e.value, e.cost = op.value, op.cost
+		c.evict(op.key, 0, 0, false)
+		return
+	}
+
+	if !c.evict(op.key, op.cost, c.policy.Estimate(op.hash), true) {
+		return
+	}
+	c.entries[op.key] = &entry[V]{value: op.value, cost: op.cost, hash: op.hash}
+	c.usedCost += op.cost
+}
+
+// evict вытесняет наименее популярные записи из случайной выборки,
+// пока для extraCost не найдется места. Ключ keep не вытесняется.
+// Если admit истинно и частота нового ключа freq ниже частоты жертвы,
+// вытеснение прекращается и возвращается false. Должен вызываться под c.mu.
+func (c *CacheTinyLFU[K, V]) evict(keep K, extraCost int64, freq int, admit bool) bool {
+	for c.usedCost+extraCost > c.maxCost {
+		var (
+			victim     K
+			victimFreq = -1
+			sampled    = 0
+		)
+
+		// порядок обхода map случаен, первые записи и есть выборка
+		for k, e := range c.entries {
+			if k == keep {
+				continue
+			}
+			if f := c.policy.Estimate(e.hash); victimFreq < 0 || f < victimFreq {
+				victim, victimFreq = k, f
+			}
+			if sampled++; sampled == evictionSampleSize {
+				break
+			}
+		}
+
+		if victimFreq < 0 {
+			return !admit
+		}
+		if admit && freq < victimFreq {
+			return false
+		}
+
+		c.usedCost -= c.entries[victim].cost
+		delete(c.entries, victim)
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+// Package deque реализует потокобезопасную двустороннюю очередь поверх list.List.
+package deque
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/xyersh/xuyacs/list"
+)
+
+var (
+	// ErrClosed возвращается при записи в закрытую очередь
+	// и при чтении из закрытой опустевшей очереди.
+	ErrClosed = errors.New("deque: closed")
+
+	// ErrFull возвращается неблокирующей записью в заполненную очередь.
+	ErrFull = errors.New("deque: full")
+
+	// ErrEmpty возвращается неблокирующим чтением из пустой очереди.
+	ErrEmpty = errors.New("deque: empty")
+)
+
+type DequeI[T any] interface {
+	PushFront(v T) error                         // добавить в начало, не блокируясь
+	PushBack(v T) error                          // добавить в конец, не блокируясь
+	PopFront() (T, error)                        // забрать из начала, не блокируясь
+	PopBack() (T, error)                         // забрать из конца, не блокируясь
+	PushBackWait(ctx context.Context, v T) error // добавить в конец, дождавшись места
+	PopFrontWait(ctx context.Context) (T, error) // забрать из начала, дождавшись элемента
+	Len() int                                    // текущее количество элементов
+	Close()                                      // закрыть очередь для записи
+}
+
+var _ DequeI[int] = (*Deque[int])(nil)
+
+// Deque — потокобезопасная двусторонняя очередь с необязательным ограничением емкости.
+// Семантика Close как у канала: запись после закрытия запрещена,
+// а чтение возвращает оставшиеся элементы и затем ErrClosed.
+type Deque[T any] struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	items    *list.List[T]
+	capacity int // 0 — без ограничения
+	closed   bool
+}
+
+// NewDeque создает очередь. capacity <= 0 означает неограниченную очередь.
+func NewDeque[T any](capacity int) *Deque[T] {
+	d := &Deque[T]{
+		items:    list.New[T](),
+		capacity: max(capacity, 0),
+	}
+	d.notEmpty = sync.NewCond(&d.mu)
+	d.notFull = sync.NewCond(&d.mu)
+	return d
+}
+
+// full сообщает, заполнена ли очередь. Вызывается под d.mu.
+func (d *Deque[T]) full() bool {
+	return d.capacity > 0 && d.items.Len() >= d.capacity
+}
+
+func (d *Deque[T]) push(v T, front bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+	if d.full() {
+		return ErrFull
+	}
+
+	if front {
+		d.items.PushFront(v)
+	} else {
+		d.items.PushBack(v)
+	}
+	d.notEmpty.Signal()
+	return nil
+}
+
+func (d *Deque[T]) pop(front bool) (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.items.Len() == 0 {
+		var zero T
+		if d.closed {
+			return zero, ErrClosed
+		}
+		return zero, ErrEmpty
+	}
+	return d.take(front), nil
+}
+
+// take извлекает элемент из непустой очереди. Вызывается под d.mu.
+func (d *Deque[T]) take(front bool) T {
+	e := d.items.Back()
+	if front {
+		e = d.items.Front()
+	}
+	v := d.items.Remove(e)
+	d.notFull.Signal()
+	return v
+}
+
+// PushFront добавляет значение в начало очереди.
+func (d *Deque[T]) PushFront(v T) error { return d.push(v, true) }
+
+// PushBack добавляет значение в конец очереди.
+func (d *Deque[T]) PushBack(v T) error { return d.push(v, false) }
+
+// PopFront извлекает значение из начала очереди.
+func (d *Deque[T]) PopFront() (T, error) { return d.pop(true) }
+
+// PopBack извлекает значение из конца очереди.
+func (d *Deque[T]) PopBack() (T, error) { return d.pop(false) }
+
+// PushBackWait добавляет значение в конец очереди, ожидая свободного места.
+// Возвращает ошибку ctx при отмене и ErrClosed, если очередь закрыли.
+func (d *Deque[T]) PushBackWait(ctx context.Context, v T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stop := d.wakeOnDone(ctx, d.notFull)
+	defer stop()
+
+	for !d.closed && d.full() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d.notFull.Wait()
+	}
+	if d.closed {
+		return ErrClosed
+	}
+
+	d.items.PushBack(v)
+	d.notEmpty.Signal()
+	return nil
+}
+
+// PopFrontWait извлекает значение из начала очереди, ожидая появления элемента.
+// Возвращает ошибку ctx при отмене и ErrClosed, если очередь закрыта и пуста.
+func (d *Deque[T]) PopFrontWait(ctx context.Context) (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stop := d.wakeOnDone(ctx, d.notEmpty)
+	defer stop()
+
+	var zero T
+	for !d.closed && d.items.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		d.notEmpty.Wait()
+	}
+	if d.items.Len() == 0 {
+		return zero, ErrClosed
+	}
+	return d.take(true), nil
+}
+
+// wakeOnDone будит ожидающих на cond при отмене ctx, чтобы они перепроверили ctx.Err.
+func (d *Deque[T]) wakeOnDone(ctx context.Context, cond *sync.Cond) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		d.mu.Lock()
+		cond.Broadcast()
+		d.mu.Unlock()
+	})
+}
+
+// Len возвращает количество элементов в очереди.
+func (d *Deque[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.items.Len()
+}
+
+// Close закрывает очередь для записи и будит всех ожидающих.
+// Повторный вызов ничего не делает.
+func (d *Deque[T]) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	d.notEmpty.Broadcast()
+	d.notFull.Broadcast()
+}
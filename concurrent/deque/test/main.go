@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xyersh/xuyacs/concurrent/deque"
+)
+
+func main() {
+	dq := deque.NewDeque[int](2)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ctx := context.Background()
+		for {
+			v, err := dq.PopFrontWait(ctx)
+			if err != nil {
+				fmt.Printf("consumer stopped: %v\n", err)
+				return
+			}
+			fmt.Printf("got: %d\n", v)
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	// производитель упирается в емкость 2 и ждет, пока потребитель разгребет очередь
+	for i := 0; i < 5; i++ {
+		if err := dq.PushBackWait(context.Background(), i); err != nil {
+			fmt.Printf("push %d: %v\n", i, err)
+		}
+	}
+	dq.Close()
+	wg.Wait()
+
+	fmt.Printf("push after close: %v\n", dq.PushBack(42))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := deque.NewDeque[int](0).PopFrontWait(ctx)
+	fmt.Printf("pop from empty with timeout: %v\n", err)
+}
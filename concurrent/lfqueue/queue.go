@@ -0,0 +1,98 @@
+// Package lfqueue реализует lock-free MPMC очередь по алгоритму Майкла-Скотта.
+package lfqueue
+
+import "sync/atomic"
+
+type QueueI[T any] interface {
+	Enqueue(v T)        // добавить значение в конец очереди
+	Dequeue() (T, bool) // забрать значение из начала очереди
+	Len() int           // приблизительное количество элементов
+}
+
+var _ QueueI[int] = (*Queue[int])(nil)
+
+type node[T any] struct {
+	value T
+	next  atomic.Pointer[node[T]]
+}
+
+// Queue — неблокирующая очередь для нескольких писателей и читателей.
+// Нулевое значение не готово к использованию, создавайте очередь через New.
+type Queue[T any] struct {
+	head atomic.Pointer[node[T]] // фиктивный узел; первый элемент — head.next
+	tail atomic.Pointer[node[T]] // последний или предпоследний узел
+	len  atomic.Int64
+}
+
+// New создает пустую очередь.
+func New[T any]() *Queue[T] {
+	q := &Queue[T]{}
+	dummy := &node[T]{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+// Enqueue добавляет значение в конец очереди.
+func (q *Queue[T]) Enqueue(v T) {
+	n := &node[T]{value: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue // tail успел измениться, перечитываем
+		}
+
+		if next != nil {
+			// tail отстал — помогаем другому писателю продвинуть его
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, n) {
+			// неудача здесь не страшна: tail продвинет следующая операция
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue извлекает значение из начала очереди.
+// Возвращает false, если очередь пуста.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+
+		if head == tail {
+			// очередь не пуста, но tail отстал — продвигаем его
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if q.head.CompareAndSwap(head, next) {
+			// next становится новым фиктивным узлом
+			v := next.value
+			var zero T
+			next.value = zero // не удерживаем значение в фиктивном узле
+			q.len.Add(-1)
+			return v, true
+		}
+	}
+}
+
+// Len возвращает приблизительное количество элементов:
+// при конкурентных операциях значение может отставать.
+func (q *Queue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/xyersh/xuyacs/concurrent/lfqueue"
+	"github.com/xyersh/xuyacs/list"
+)
+
+const (
+	producers   = 8
+	consumers   = 8
+	perProducer = 20000
+)
+
+// stress проверяет, что каждое значение извлечено ровно один раз.
+// Запускать с -race: go run -race ./concurrent/lfqueue/test
+func stress() {
+	q := lfqueue.New[int]()
+	seen := make([]int32, producers*perProducer)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	var cwg sync.WaitGroup
+	done := make(chan struct{})
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, ok := q.Dequeue()
+				if !ok {
+					select {
+					case <-done:
+						// писатели закончили: дочищаем остаток
+						if v, ok = q.Dequeue(); !ok {
+							return
+						}
+					default:
+						continue
+					}
+				}
+				mu.Lock()
+				seen[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+	cwg.Wait()
+
+	for v, cnt := range seen {
+		if cnt != 1 {
+			panic(fmt.Sprintf("value %d dequeued %d times", v, cnt))
+		}
+	}
+	fmt.Printf("stress: %d values passed through, len after: %d\n", len(seen), q.Len())
+}
+
+// mutexList — список под мьютексом для сравнения.
+type mutexList struct {
+	mu sync.Mutex
+	l  *list.List[int]
+}
+
+func (m *mutexList) Enqueue(v int) {
+	m.mu.Lock()
+	m.l.PushBack(v)
+	m.mu.Unlock()
+}
+
+func (m *mutexList) Dequeue() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.l.Front(); e != nil {
+		return m.l.Remove(e), true
+	}
+	return 0, false
+}
+
+func bench(name string, q interface {
+	Enqueue(int)
+	Dequeue() (int, bool)
+}) {
+	res := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				q.Enqueue(1)
+				q.Dequeue()
+			}
+		})
+	})
+	fmt.Printf("%-12s %s %s\n", name, res.String(), res.MemString())
+}
+
+func main() {
+	stress()
+
+	bench("lfqueue", lfqueue.New[int]())
+	bench("mutex+list", &mutexList{l: list.New[int]()})
+}
@@ -0,0 +1,20 @@
+//go:build xuyacsdebug
+
+package list
+
+import "fmt"
+
+// check вызывается после каждой модификации и паникует, если Validate нашел ошибку.
+// Прогон: go run -tags xuyacsdebug ./list/test/debug
+func (l *List[T]) check() {
+	if err := l.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// checkOwner паникует, если e принадлежит другому списку.
+func (l *List[T]) checkOwner(e *Element[T]) {
+	if e.list != nil && e.list != l {
+		panic(fmt.Errorf("%w: element belongs to another list", ErrInvalid))
+	}
+}
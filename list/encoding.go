@@ -0,0 +1,106 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// FromSlice создает список из элементов среза в том же порядке.
+func FromSlice[T any](s []T) *List[T] {
+	l := New[T]()
+	for _, v := range s {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Collect создает список из значений итератора.
+func Collect[T any](seq iter.Seq[T]) *List[T] {
+	l := New[T]()
+	for v := range seq {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// ToSlice возвращает значения списка в виде нового среза.
+func (l *List[T]) ToSlice() []T {
+	s := make([]T, 0, l.Len())
+	for v := range l.All() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// reset заменяет содержимое списка значениями из s.
+func (l *List[T]) reset(s []T) {
+	l.Init()
+	for _, v := range s {
+		l.PushBack(v)
+	}
+}
+
+// MarshalJSON кодирует список как JSON-массив.
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.ToSlice())
+}
+
+// UnmarshalJSON заменяет содержимое списка элементами JSON-массива.
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	l.reset(s)
+	return nil
+}
+
+// GobEncode кодирует список в gob как срез значений.
+func (l *List[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(l.ToSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode заменяет содержимое списка значениями, закодированными GobEncode.
+func (l *List[T]) GobDecode(data []byte) error {
+	var s []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+	l.reset(s)
+	return nil
+}
+
+// Format реализует fmt.Formatter.
+// %v и %s выводят список компактно: [1 2 3];
+// %+v — подробно: тип, длина и по одному значению на строку;
+// остальные глаголы применяются к каждому значению: %d, %q, %x и т.д.
+func (l *List[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "type: %T   len: %d\n", l, l.Len())
+		for v := range l.All() {
+			fmt.Fprintf(f, "%+v\n", v)
+		}
+		return
+	}
+
+	elemFormat := fmt.FormatString(f, verb)
+	if verb == 's' {
+		elemFormat = "%v"
+	}
+
+	f.Write([]byte{'['})
+	for i, v := range l.Enumerate() {
+		if i > 0 {
+			f.Write([]byte{' '})
+		}
+		fmt.Fprintf(f, elemFormat, v)
+	}
+	f.Write([]byte{']'})
+}
@@ -0,0 +1,101 @@
+package list
+
+import "iter"
+
+// Backward возвращает итератор по значениям от конца списка к началу.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Back(); e != nil; e = e.Prev() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Elements возвращает итератор по элементам от начала списка к концу.
+// Текущий элемент можно удалить через Remove прямо во время обхода.
+func (l *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Front(); e != nil; {
+			// следующий элемент запоминаем до yield: после Remove у e нет ссылок
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Enumerate возвращает итератор по парам (индекс, значение) от начала списка к концу.
+func (l *List[T]) Enumerate() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := l.Front(); e != nil; e = e.Next() {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Cursor — курсор для ручного обхода списка с возможностью удалять текущий элемент.
+//
+//	for c := l.Cursor(); c.Next(); {
+//		if c.Value() == 0 {
+//			c.Remove()
+//		}
+//	}
+type Cursor[T any] struct {
+	list      *List[T]
+	cur, next *Element[T]
+	started   bool
+}
+
+// Cursor создает курсор, стоящий перед первым элементом списка.
+func (l *List[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{list: l}
+}
+
+// Next переходит к следующему элементу и сообщает, есть ли он.
+func (c *Cursor[T]) Next() bool {
+	if !c.started {
+		c.started = true
+		c.next = c.list.Front()
+	}
+
+	c.cur = c.next
+	if c.cur == nil {
+		return false
+	}
+	c.next = c.cur.Next()
+	return true
+}
+
+// Element возвращает текущий элемент или nil, если он удален или обход завершен.
+func (c *Cursor[T]) Element() *Element[T] {
+	if c.cur == nil || c.cur.list != c.list {
+		return nil
+	}
+	return c.cur
+}
+
+// Value возвращает значение текущего элемента или нулевое значение, если элемента нет.
+func (c *Cursor[T]) Value() T {
+	if e := c.Element(); e != nil {
+		return e.Value
+	}
+	var zero T
+	return zero
+}
+
+// Remove удаляет текущий элемент из списка и возвращает его значение.
+// Обход продолжается со следующего элемента. Курсор забывает удаленный элемент:
+// в списке с пулом тот же *Element может быть сразу выдан другой вставке.
+func (c *Cursor[T]) Remove() T {
+	v := c.list.Remove(c.cur)
+	c.cur = nil
+	return v
+}
@@ -3,7 +3,6 @@ package list
 import (
 	"fmt"
 	"iter"
-	"strings"
 )
 
 // Element представляет узел в списке.
@@ -41,11 +40,29 @@ type ListI[T any] interface {
 	Back() *Element[T]
 	PushFront(v T) *Element[T]
 	PushBack(v T) *Element[T]
+	InsertBefore(v T, mark *Element[T]) *Element[T]
+	InsertAfter(v T, mark *Element[T]) *Element[T]
+	PushBackList(other *List[T])
+	PushFrontList(other *List[T])
 	Remove(e *Element[T]) T
 
 	MoveToFront(e *Element[T])
 	MoveToBack(e *Element[T])
+	MoveBefore(e, mark *Element[T])
+	MoveAfter(e, mark *Element[T])
+
+	Reverse()
+	Sort(cmp func(a, b T) int)
+	Find(pred func(T) bool) *Element[T]
+	RemoveIf(pred func(T) bool) int
+	Splice(mark *Element[T], other *List[T], first, last *Element[T])
+	Validate() error
+
 	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+	Elements() iter.Seq[*Element[T]]
+	Enumerate() iter.Seq2[int, T]
+	ToSlice() []T
 	String() string
 }
 
@@ -54,6 +71,7 @@ type ListI[T any] interface {
 type List[T any] struct {
 	root Element[T] // Страж (sentinel), упрощающий логику вставки/удаления
 	len  int        // Текущая длина
+	pool *Pool[T]   // Пул элементов, nil — без переиспользования
 }
 
 // Init инициализирует или очищает список.
@@ -103,11 +121,17 @@ func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
 	e.next.prev = e
 	e.list = l
 	l.len++
+	l.check()
 	return e
 }
 
 // insertValue — вспомогательный метод для вставки значения.
 func (l *List[T]) insertValue(v T, at *Element[T]) *Element[T] {
+	if l.pool != nil {
+		e := l.pool.get()
+		e.Value = v
+		return l.insert(e, at)
+	}
 	return l.insert(&Element[T]{Value: v}, at)
 }
 
@@ -123,8 +147,50 @@ func (l *List[T]) PushBack(v T) *Element[T] {
 	return l.insertValue(v, l.root.prev)
 }
 
+// InsertBefore вставляет значение перед mark и возвращает новый элемент.
+// Если mark не принадлежит списку l, список не изменяется и возвращается nil.
+func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	l.checkOwner(mark)
+	if mark.list != l {
+		return nil
+	}
+	return l.insertValue(v, mark.prev)
+}
+
+// InsertAfter вставляет значение после mark и возвращает новый элемент.
+// Если mark не принадлежит списку l, список не изменяется и возвращается nil.
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	l.checkOwner(mark)
+	if mark.list != l {
+		return nil
+	}
+	return l.insertValue(v, mark)
+}
+
+// PushBackList вставляет копию списка other в конец списка l.
+// l и other могут совпадать.
+func (l *List[T]) PushBackList(other *List[T]) {
+	l.lazyInit()
+	// длину фиксируем заранее: при l == other список растет во время обхода
+	for i, e := other.Len(), other.Front(); i > 0; i, e = i-1, e.Next() {
+		l.insertValue(e.Value, l.root.prev)
+	}
+}
+
+// PushFrontList вставляет копию списка other в начало списка l.
+// l и other могут совпадать.
+func (l *List[T]) PushFrontList(other *List[T]) {
+	l.lazyInit()
+	for i, e := other.Len(), other.Back(); i > 0; i, e = i-1, e.Prev() {
+		l.insertValue(e.Value, &l.root)
+	}
+}
+
 // Remove удаляет элемент из списка.
+// Если у списка есть пул, элемент возвращается в пул и больше не должен использоваться.
 func (l *List[T]) Remove(e *Element[T]) T {
+	l.checkOwner(e)
+	v := e.Value
 	if e.list == l {
 		e.prev.next = e.next
 		e.next.prev = e.prev
@@ -132,8 +198,13 @@ func (l *List[T]) Remove(e *Element[T]) T {
 		e.prev = nil
 		e.list = nil
 		l.len--
+
+		if l.pool != nil {
+			l.pool.put(e)
+		}
+		l.check()
 	}
-	return e.Value
+	return v
 }
 
 // move перемещает элемент e так, чтобы он оказался после элемента at.
@@ -152,11 +223,13 @@ func (l *List[T]) move(e, at *Element[T]) {
 	e.next = at.next
 	e.prev.next = e
 	e.next.prev = e
+	l.check()
 }
 
 // MoveToFront перемещает элемент e в начало списка.
 // Если e не принадлежит списку l, список не изменяется.
 func (l *List[T]) MoveToFront(e *Element[T]) {
+	l.checkOwner(e)
 	if e.list != l || l.root.next == e {
 		return
 	}
@@ -167,6 +240,7 @@ func (l *List[T]) MoveToFront(e *Element[T]) {
 // MoveToBack перемещает элемент e в конец списка.
 // Если e не принадлежит списку l, список не изменяется.
 func (l *List[T]) MoveToBack(e *Element[T]) {
+	l.checkOwner(e)
 	if e.list != l || l.root.prev == e {
 		return
 	}
@@ -174,6 +248,28 @@ func (l *List[T]) MoveToBack(e *Element[T]) {
 	l.move(e, l.root.prev)
 }
 
+// MoveBefore перемещает элемент e на позицию перед mark.
+// Если e или mark не принадлежат списку l или e == mark, список не изменяется.
+func (l *List[T]) MoveBefore(e, mark *Element[T]) {
+	l.checkOwner(e)
+	l.checkOwner(mark)
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark.prev)
+}
+
+// MoveAfter перемещает элемент e на позицию после mark.
+// Если e или mark не принадлежат списку l или e == mark, список не изменяется.
+func (l *List[T]) MoveAfter(e, mark *Element[T]) {
+	l.checkOwner(e)
+	l.checkOwner(mark)
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark)
+}
+
 func (l *List[T]) All() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for e := l.Front(); e != nil; e = e.Next() {
@@ -184,12 +280,7 @@ func (l *List[T]) All() iter.Seq[T] {
 	}
 }
 
+// String возвращает компактное представление списка: [1 2 3].
 func (l *List[T]) String() string {
-	bldr := strings.Builder{}
-	bldr.Write([]byte(fmt.Sprintf("type: %T   len: %d\n", l, l.Len())))
-	for v := range l.All() {
-		bldr.Write([]byte(fmt.Sprintf("%+v\n", v)))
-	}
-
-	return bldr.String()
+	return fmt.Sprintf("%v", l)
 }
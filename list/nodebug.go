@@ -0,0 +1,7 @@
+//go:build !xuyacsdebug
+
+package list
+
+func (l *List[T]) check() {}
+
+func (l *List[T]) checkOwner(*Element[T]) {}
@@ -0,0 +1,177 @@
+package list
+
+// Reverse разворачивает список на месте.
+func (l *List[T]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+
+	// меняем местами next и prev у всех элементов, включая стража
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		if e = e.prev; e == &l.root {
+			l.check()
+			return
+		}
+	}
+}
+
+// Find возвращает первый элемент, значение которого удовлетворяет pred, или nil.
+func (l *List[T]) Find(pred func(T) bool) *Element[T] {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// RemoveIf удаляет все элементы, значения которых удовлетворяют pred,
+// и возвращает количество удаленных.
+func (l *List[T]) RemoveIf(pred func(T) bool) int {
+	removed := 0
+	for e := range l.Elements() {
+		if pred(e.Value) {
+			l.Remove(e)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Sort сортирует список по cmp (отрицательный результат — a < b).
+// Сортировка устойчивая (слиянием снизу вверх), элементы перевязываются без аллокаций,
+// так что ранее полученные указатели на элементы остаются валидными.
+func (l *List[T]) Sort(cmp func(a, b T) int) {
+	if l.len < 2 {
+		return
+	}
+
+	// размыкаем кольцо: дальше работаем с односвязной цепочкой по next
+	head := l.root.next
+	l.root.prev.next = nil
+
+	for width := 1; ; width *= 2 {
+		var (
+			tail   *Element[T] // последний элемент уже слитой части
+			p      = head
+			merges = 0
+		)
+		head = nil
+
+		for p != nil {
+			merges++
+
+			// q — начало второй серии длиной до width
+			q, pSize := p, 0
+			for pSize < width && q != nil {
+				q = q.next
+				pSize++
+			}
+			qSize := width
+
+			for pSize > 0 || (qSize > 0 && q != nil) {
+				var e *Element[T]
+				// при равенстве берем из первой серии — это дает устойчивость
+				if pSize == 0 || (qSize > 0 && q != nil && cmp(q.Value, p.Value) < 0) {
+					e, q = q, q.next
+					qSize--
+				} else {
+					e, p = p, p.next
+					pSize--
+				}
+
+				if tail == nil {
+					head = e
+				} else {
+					tail.next = e
+				}
+				tail = e
+			}
+			p = q
+		}
+		tail.next = nil
+
+		if merges <= 1 {
+			break
+		}
+	}
+
+	// восстанавливаем prev и замыкаем кольцо через стража
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+	l.check()
+}
+
+// Splice переносит диапазон элементов [first, last] из списка other в список l
+// сразу после mark. Сами элементы не копируются: указатели на них остаются валидными.
+// Перевязка выполняется за O(1), но владельца у перенесенных элементов приходится
+// обновить, поэтому итоговая сложность — O(длины диапазона).
+// Если mark не принадлежит l, first или last не принадлежат other, last не достижим
+// из first или (при l == other) mark попадает в диапазон, списки не изменяются.
+func (l *List[T]) Splice(mark *Element[T], other *List[T], first, last *Element[T]) {
+	if mark.list != l || first.list != other || last.list != other {
+		return
+	}
+
+	n := 1
+	for e := first; e != last; e = e.next {
+		if e == mark || e.next == &other.root {
+			return
+		}
+		n++
+	}
+	if last == mark {
+		return
+	}
+
+	// вырезаем диапазон из other
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	other.len -= n
+
+	// вставляем после mark
+	first.prev = mark
+	last.next = mark.next
+	mark.next.prev = last
+	mark.next = first
+	l.len += n
+
+	if l != other {
+		for e := first; ; e = e.next {
+			e.list = l
+			if e == last {
+				break
+			}
+		}
+	}
+	l.check()
+	other.check()
+}
+
+// Map строит новый список из результатов fn для каждого значения l.
+func Map[T, U any](l *List[T], fn func(T) U) *List[U] {
+	out := New[U]()
+	for v := range l.All() {
+		out.PushBack(fn(v))
+	}
+	return out
+}
+
+// Filter строит новый список из значений l, удовлетворяющих pred.
+func Filter[T any](l *List[T], pred func(T) bool) *List[T] {
+	out := New[T]()
+	for v := range l.All() {
+		if pred(v) {
+			out.PushBack(v)
+		}
+	}
+	return out
+}
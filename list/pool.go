@@ -0,0 +1,56 @@
+package list
+
+// Pool — пул элементов для переиспользования в списках.
+// Элементы, удаленные из списка с пулом, возвращаются в пул и выдаются
+// повторно при следующих вставках. Поэтому Remove делает недействительными
+// сохраненные *Element и курсоры, стоящие на удаленном элементе (кроме
+// удаления через сам Cursor.Remove): после следующей вставки они будут
+// указывать на чужое значение. Pool не потокобезопасен.
+type Pool[T any] struct {
+	free      *Element[T] // свободные элементы, связанные через next
+	chunkSize int         // сколько элементов выделять за раз
+}
+
+// NewPool создает пул, который выделяет элементы по одному
+// и переиспользует удаленные.
+func NewPool[T any]() *Pool[T] {
+	return &Pool[T]{chunkSize: 1}
+}
+
+// NewArena создает пул, который выделяет элементы блоками по chunkSize штук:
+// одна аллокация на блок вместо одной на каждую вставку.
+func NewArena[T any](chunkSize int) *Pool[T] {
+	return &Pool[T]{chunkSize: max(chunkSize, 1)}
+}
+
+// NewWithPool создает список, берущий элементы из пула p.
+func NewWithPool[T any](p *Pool[T]) *List[T] {
+	l := New[T]()
+	l.pool = p
+	return l
+}
+
+// get выдает свободный элемент, при необходимости выделяя новый блок.
+func (p *Pool[T]) get() *Element[T] {
+	if p.free == nil {
+		chunk := make([]Element[T], p.chunkSize)
+		for i := range chunk {
+			p.put(&chunk[i])
+		}
+	}
+
+	e := p.free
+	p.free = e.next
+	e.next = nil
+	return e
+}
+
+// put возвращает элемент в пул. Значение обнуляется, чтобы не удерживать память.
+func (p *Pool[T]) put(e *Element[T]) {
+	var zero T
+	e.Value = zero
+	e.prev = nil
+	e.list = nil
+	e.next = p.free
+	p.free = e
+}
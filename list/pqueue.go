@@ -0,0 +1,195 @@
+package list
+
+type PriorityQueueI[T any] interface {
+	Push(v T)        // добавить значение
+	Pop() (T, bool)  // извлечь минимальное значение
+	Peek() (T, bool) // посмотреть минимальное значение
+	Len() int        // количество значений
+}
+
+var _ PriorityQueueI[int] = (*PriorityQueue[int])(nil)
+
+// PriorityQueue — очередь с приоритетом на двоичной куче.
+// Первым извлекается минимальный по cmp элемент (отрицательный результат — a < b).
+type PriorityQueue[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+}
+
+// NewPriorityQueue создает очередь с приоритетом с компаратором cmp.
+func NewPriorityQueue[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{cmp: cmp}
+}
+
+func (pq *PriorityQueue[T]) less(i, j int) bool { return pq.cmp(pq.items[i], pq.items[j]) < 0 }
+
+func (pq *PriorityQueue[T]) swap(i, j int) { pq.items[i], pq.items[j] = pq.items[j], pq.items[i] }
+
+// Len возвращает количество элементов.
+func (pq *PriorityQueue[T]) Len() int { return len(pq.items) }
+
+// Push добавляет значение за O(log n).
+func (pq *PriorityQueue[T]) Push(v T) {
+	pq.items = append(pq.items, v)
+	siftUp(len(pq.items)-1, pq.less, pq.swap)
+}
+
+// Pop извлекает минимальное значение за O(log n).
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	n := len(pq.items) - 1
+	if n < 0 {
+		return zero, false
+	}
+
+	pq.swap(0, n)
+	v := pq.items[n]
+	pq.items[n] = zero // не удерживаем значение в срезе
+	pq.items = pq.items[:n]
+	siftDown(0, n, pq.less, pq.swap)
+	return v, true
+}
+
+// Peek возвращает минимальное значение, не извлекая его.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0], true
+}
+
+// Handle — ссылка на элемент IndexedPriorityQueue для Update и Remove.
+type Handle[T any, P any] struct {
+	Value    T
+	priority P
+	index    int // позиция в куче, -1 после извлечения
+}
+
+// Priority возвращает текущий приоритет элемента.
+func (h *Handle[T, P]) Priority() P { return h.priority }
+
+// IndexedPriorityQueue — очередь с приоритетом, где приоритет элемента
+// можно менять и элемент можно удалять по ссылке-хендлу за O(log n).
+// Первым извлекается элемент с минимальным по cmp приоритетом.
+type IndexedPriorityQueue[T any, P any] struct {
+	items []*Handle[T, P]
+	cmp   func(a, b P) int
+}
+
+// NewIndexedPriorityQueue создает индексированную очередь с компаратором приоритетов cmp.
+func NewIndexedPriorityQueue[T any, P any](cmp func(a, b P) int) *IndexedPriorityQueue[T, P] {
+	return &IndexedPriorityQueue[T, P]{cmp: cmp}
+}
+
+func (pq *IndexedPriorityQueue[T, P]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].priority, pq.items[j].priority) < 0
+}
+
+func (pq *IndexedPriorityQueue[T, P]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// Len возвращает количество элементов.
+func (pq *IndexedPriorityQueue[T, P]) Len() int { return len(pq.items) }
+
+// Push добавляет значение с приоритетом и возвращает его хендл.
+func (pq *IndexedPriorityQueue[T, P]) Push(v T, priority P) *Handle[T, P] {
+	h := &Handle[T, P]{Value: v, priority: priority, index: len(pq.items)}
+	pq.items = append(pq.items, h)
+	siftUp(h.index, pq.less, pq.swap)
+	return h
+}
+
+// Peek возвращает хендл элемента с минимальным приоритетом, не извлекая его.
+func (pq *IndexedPriorityQueue[T, P]) Peek() (*Handle[T, P], bool) {
+	if len(pq.items) == 0 {
+		return nil, false
+	}
+	return pq.items[0], true
+}
+
+// Pop извлекает элемент с минимальным приоритетом.
+func (pq *IndexedPriorityQueue[T, P]) Pop() (*Handle[T, P], bool) {
+	if len(pq.items) == 0 {
+		return nil, false
+	}
+	return pq.removeAt(0), true
+}
+
+// Update меняет приоритет элемента. Если хендл уже извлечен, очередь не изменяется.
+func (pq *IndexedPriorityQueue[T, P]) Update(h *Handle[T, P], priority P) {
+	if !pq.owns(h) {
+		return
+	}
+	h.priority = priority
+	if !siftDown(h.index, len(pq.items), pq.less, pq.swap) {
+		siftUp(h.index, pq.less, pq.swap)
+	}
+}
+
+// Remove удаляет элемент по хендлу и возвращает его значение.
+// Возвращает false, если хендл уже извлечен.
+func (pq *IndexedPriorityQueue[T, P]) Remove(h *Handle[T, P]) (T, bool) {
+	if !pq.owns(h) {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(h.index).Value, true
+}
+
+// owns проверяет, что хендл принадлежит этой очереди и еще не извлечен.
+func (pq *IndexedPriorityQueue[T, P]) owns(h *Handle[T, P]) bool {
+	return h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+func (pq *IndexedPriorityQueue[T, P]) removeAt(i int) *Handle[T, P] {
+	n := len(pq.items) - 1
+	if i != n {
+		pq.swap(i, n)
+		if !siftDown(i, n, pq.less, pq.swap) {
+			siftUp(i, pq.less, pq.swap)
+		}
+	}
+
+	h := pq.items[n]
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	h.index = -1
+	return h
+}
+
+// siftUp поднимает элемент j к корню кучи, пока он меньше родителя.
+func siftUp(j int, less func(i, j int) bool, swap func(i, j int)) {
+	for j > 0 {
+		parent := (j - 1) / 2
+		if !less(j, parent) {
+			break
+		}
+		swap(parent, j)
+		j = parent
+	}
+}
+
+// siftDown опускает элемент i в куче из n элементов.
+// Возвращает true, если элемент сдвинулся.
+func siftDown(i, n int, less func(i, j int) bool, swap func(i, j int)) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= n || child < 0 {
+			break
+		}
+		if right := child + 1; right < n && less(right, child) {
+			child = right
+		}
+		if !less(child, i) {
+			break
+		}
+		swap(i, child)
+		i = child
+	}
+	return i > start
+}
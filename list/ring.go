@@ -0,0 +1,180 @@
+package list
+
+import (
+	"iter"
+	"sync"
+)
+
+// RingPolicy определяет поведение RingBuffer при заполнении.
+type RingPolicy int
+
+const (
+	// OverwriteOldest — новый элемент вытесняет самый старый.
+	OverwriteOldest RingPolicy = iota
+	// RejectWhenFull — новый элемент отбрасывается, буфер не меняется.
+	RejectWhenFull
+)
+
+type RingBufferI[T any] interface {
+	Push(v T) bool      // добавить в конец; false, если элемент отброшен
+	Pop() (T, bool)     // забрать самый старый элемент
+	Peek() (T, bool)    // посмотреть самый старый элемент
+	At(i int) (T, bool) // i-й элемент от самого старого
+	Len() int           // текущее количество элементов
+	Cap() int           // емкость
+	All() iter.Seq[T]   // обход от самого старого к самому новому
+}
+
+var (
+	_ RingBufferI[int] = (*RingBuffer[int])(nil)
+	_ RingBufferI[int] = (*SyncRingBuffer[int])(nil)
+)
+
+// RingBuffer — кольцевой буфер фиксированной емкости на срезе (FIFO).
+// Не потокобезопасен, для конкурентного доступа используйте SyncRingBuffer.
+type RingBuffer[T any] struct {
+	data   []T
+	head   int // индекс самого старого элемента
+	len    int
+	policy RingPolicy
+}
+
+// NewRingBuffer создает кольцевой буфер заданной емкости.
+func NewRingBuffer[T any](capacity int, policy RingPolicy) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("`capacity` must be positive")
+	}
+	return &RingBuffer[T]{
+		data:   make([]T, capacity),
+		policy: policy,
+	}
+}
+
+// idx переводит логический индекс (0 — самый старый) в индекс среза.
+func (r *RingBuffer[T]) idx(i int) int {
+	return (r.head + i) % len(r.data)
+}
+
+// Push добавляет значение в конец буфера.
+// Возвращает false, если буфер полон и политика RejectWhenFull.
+func (r *RingBuffer[T]) Push(v T) bool {
+	if r.len == len(r.data) {
+		if r.policy == RejectWhenFull {
+			return false
+		}
+		// перезаписываем самый старый и сдвигаем начало
+		r.data[r.head] = v
+		r.head = r.idx(1)
+		return true
+	}
+
+	r.data[r.idx(r.len)] = v
+	r.len++
+	return true
+}
+
+// Pop извлекает самый старый элемент.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.len == 0 {
+		return zero, false
+	}
+
+	v := r.data[r.head]
+	r.data[r.head] = zero // не удерживаем значение в срезе
+	r.head = r.idx(1)
+	r.len--
+	return v, true
+}
+
+// Peek возвращает самый старый элемент, не извлекая его.
+func (r *RingBuffer[T]) Peek() (T, bool) {
+	return r.At(0)
+}
+
+// At возвращает i-й элемент, считая от самого старого.
+func (r *RingBuffer[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.len {
+		var zero T
+		return zero, false
+	}
+	return r.data[r.idx(i)], true
+}
+
+// Len возвращает количество элементов в буфере.
+func (r *RingBuffer[T]) Len() int { return r.len }
+
+// Cap возвращает емкость буфера.
+func (r *RingBuffer[T]) Cap() int { return len(r.data) }
+
+// All возвращает итератор по элементам от самого старого к самому новому.
+func (r *RingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.len; i++ {
+			if !yield(r.data[r.idx(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// SyncRingBuffer — потокобезопасная обертка над RingBuffer.
+type SyncRingBuffer[T any] struct {
+	mu sync.RWMutex
+	rb *RingBuffer[T]
+}
+
+// NewSyncRingBuffer создает потокобезопасный кольцевой буфер.
+func NewSyncRingBuffer[T any](capacity int, policy RingPolicy) *SyncRingBuffer[T] {
+	return &SyncRingBuffer[T]{rb: NewRingBuffer[T](capacity, policy)}
+}
+
+func (s *SyncRingBuffer[T]) Push(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rb.Push(v)
+}
+
+func (s *SyncRingBuffer[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rb.Pop()
+}
+
+func (s *SyncRingBuffer[T]) Peek() (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rb.Peek()
+}
+
+func (s *SyncRingBuffer[T]) At(i int) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rb.At(i)
+}
+
+func (s *SyncRingBuffer[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rb.Len()
+}
+
+func (s *SyncRingBuffer[T]) Cap() int { return s.rb.Cap() }
+
+// All обходит снимок буфера, сделанный на момент начала обхода.
+func (s *SyncRingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.RLock()
+		snapshot := make([]T, 0, s.rb.Len())
+		for v := range s.rb.All() {
+			snapshot = append(snapshot, v)
+		}
+		s.mu.RUnlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
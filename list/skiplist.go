@@ -0,0 +1,276 @@
+package list
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+const (
+	skipMaxLevel = 32   // максимальная высота башни узла
+	skipP        = 0.25 // вероятность подняться на уровень выше
+)
+
+type SkipListI[K any, V any] interface {
+	Set(key K, value V)               // добавить или обновить значение по ключу
+	Get(key K) (V, bool)              // получить значение по ключу
+	Delete(key K) bool                // удалить ключ
+	Len() int                         // количество ключей
+	Rank(key K) (int, bool)           // позиция ключа в порядке сортировки
+	At(i int) (K, V, bool)            // i-й по порядку ключ
+	Range(from, to K) iter.Seq2[K, V] // ключи из [from, to)
+	All() iter.Seq2[K, V]             // все ключи по возрастанию
+	Backward() iter.Seq2[K, V]        // все ключи по убыванию
+}
+
+var _ SkipListI[int, int] = (*SkipList[int, int])(nil)
+
+// skipLevel — ссылка узла на уровне level.
+// span — сколько узлов нижнего уровня перепрыгивает ссылка, нужно для Rank и At.
+type skipLevel[K any, V any] struct {
+	next *skipNode[K, V]
+	span int
+}
+
+type skipNode[K any, V any] struct {
+	key    K
+	value  V
+	prev   *skipNode[K, V] // предыдущий узел на нижнем уровне, для обратного обхода
+	levels []skipLevel[K, V]
+}
+
+// SkipList — упорядоченная по ключу коллекция на списке с пропусками.
+// Вставка, поиск, удаление, Rank и At выполняются в среднем за O(log n).
+type SkipList[K any, V any] struct {
+	head  *skipNode[K, V] // страж, ключа не хранит
+	tail  *skipNode[K, V]
+	level int // текущая высота списка
+	len   int
+	cmp   func(a, b K) int
+}
+
+// NewSkipList создает список с пропусками для упорядоченных ключей.
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFunc[K, V](cmp.Compare[K])
+}
+
+// NewSkipListFunc создает список с пропусками с собственным компаратором
+// (отрицательный результат — a < b).
+func NewSkipListFunc[K any, V any](cmp func(a, b K) int) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:  &skipNode[K, V]{levels: make([]skipLevel[K, V], skipMaxLevel)},
+		level: 1,
+		cmp:   cmp,
+	}
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skipMaxLevel && rand.Float64() < skipP {
+		level++
+	}
+	return level
+}
+
+// Len возвращает количество ключей.
+func (s *SkipList[K, V]) Len() int { return s.len }
+
+// findPath заполняет update последними узлами с ключом < key на каждом уровне,
+// а rank — их позициями (позиция head равна 0, первого элемента — 1).
+func (s *SkipList[K, V]) findPath(key K, update *[skipMaxLevel]*skipNode[K, V], rank *[skipMaxLevel]int) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].next != nil && s.cmp(x.levels[i].next.key, key) < 0 {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+}
+
+// Set добавляет значение по ключу. Если ключ уже есть, обновляет значение.
+func (s *SkipList[K, V]) Set(key K, value V) {
+	var (
+		update [skipMaxLevel]*skipNode[K, V]
+		rank   [skipMaxLevel]int
+	)
+	s.findPath(key, &update, &rank)
+
+	if x := update[0].levels[0].next; x != nil && s.cmp(x.key, key) == 0 {
+		x.value = value
+		return
+	}
+
+	level := randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = s.head
+			s.head.levels[i].span = s.len
+		}
+		s.level = level
+	}
+
+	x := &skipNode[K, V]{key: key, value: value, levels: make([]skipLevel[K, V], level)}
+	for i := 0; i < level; i++ {
+		x.levels[i].next = update[i].levels[i].next
+		update[i].levels[i].next = x
+
+		// делим пролет предшественника между ним и новым узлом
+		x.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+	// уровни выше нового узла теперь перепрыгивают на один узел больше
+	for i := level; i < s.level; i++ {
+		update[i].levels[i].span++
+	}
+
+	if update[0] != s.head {
+		x.prev = update[0]
+	}
+	if next := x.levels[0].next; next != nil {
+		next.prev = x
+	} else {
+		s.tail = x
+	}
+	s.len++
+}
+
+// Get возвращает значение по ключу.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.cmp(x.levels[i].next.key, key) < 0 {
+			x = x.levels[i].next
+		}
+	}
+
+	if x = x.levels[0].next; x != nil && s.cmp(x.key, key) == 0 {
+		return x.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete удаляет ключ. Возвращает true, если ключ был в списке.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var (
+		update [skipMaxLevel]*skipNode[K, V]
+		rank   [skipMaxLevel]int
+	)
+	s.findPath(key, &update, &rank)
+
+	x := update[0].levels[0].next
+	if x == nil || s.cmp(x.key, key) != 0 {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].levels[i].next == x {
+			update[i].levels[i].span += x.levels[i].span - 1
+			update[i].levels[i].next = x.levels[i].next
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+
+	if next := x.levels[0].next; next != nil {
+		next.prev = x.prev
+	} else {
+		s.tail = x.prev
+	}
+
+	for s.level > 1 && s.head.levels[s.level-1].next == nil {
+		s.head.levels[s.level-1].span = 0
+		s.level--
+	}
+	s.len--
+	return true
+}
+
+// Rank возвращает позицию ключа в порядке возрастания (с нуля).
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.cmp(x.levels[i].next.key, key) <= 0 {
+			rank += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if x != s.head && s.cmp(x.key, key) == 0 {
+			return rank - 1, true
+		}
+	}
+	return 0, false
+}
+
+// At возвращает ключ и значение на позиции i в порядке возрастания (с нуля).
+func (s *SkipList[K, V]) At(i int) (K, V, bool) {
+	if i < 0 || i >= s.len {
+		var (
+			zeroK K
+			zeroV V
+		)
+		return zeroK, zeroV, false
+	}
+
+	target := i + 1 // позиции в пролетах считаются с единицы
+	traversed := 0
+	x := s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for x.levels[lvl].next != nil && traversed+x.levels[lvl].span <= target {
+			traversed += x.levels[lvl].span
+			x = x.levels[lvl].next
+		}
+		if traversed == target {
+			break
+		}
+	}
+	return x.key, x.value, true
+}
+
+// seek возвращает первый узел с ключом >= key.
+func (s *SkipList[K, V]) seek(key K) *skipNode[K, V] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.cmp(x.levels[i].next.key, key) < 0 {
+			x = x.levels[i].next
+		}
+	}
+	return x.levels[0].next
+}
+
+// Range возвращает итератор по ключам из полуинтервала [from, to) по возрастанию.
+func (s *SkipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.seek(from); x != nil && s.cmp(x.key, to) < 0; x = x.levels[0].next {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// All возвращает итератор по всем ключам по возрастанию.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.levels[0].next; x != nil; x = x.levels[0].next {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward возвращает итератор по всем ключам по убыванию.
+func (s *SkipList[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.tail; x != nil; x = x.prev {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
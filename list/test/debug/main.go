@@ -0,0 +1,73 @@
+//go:build xuyacsdebug
+
+// Прогон списка в отладочной сборке: каждая модификация проверяет инварианты.
+//
+//	go run -tags xuyacsdebug ./list/test/debug
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/xyersh/xuyacs/list"
+)
+
+func main() {
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	l := list.New[int]()
+	var elems []*list.Element[int]
+	for i := 0; i < 1000; i++ {
+		elems = append(elems, l.PushBack(rnd.IntN(100)))
+	}
+
+	for i := 0; i < 1000; i++ {
+		e := elems[rnd.IntN(len(elems))]
+		switch rnd.IntN(4) {
+		case 0:
+			l.MoveToFront(e)
+		case 1:
+			l.MoveToBack(e)
+		case 2:
+			l.MoveAfter(e, elems[rnd.IntN(len(elems))])
+		case 3:
+			l.MoveBefore(e, elems[rnd.IntN(len(elems))])
+		}
+	}
+
+	other := list.FromSlice([]int{-1, -2, -3, -4})
+	l.Splice(l.Front(), other, other.Front().Next(), other.Back())
+	if l.Len() != 1003 || other.Len() != 1 {
+		panic(fmt.Sprintf("splice: len %d and %d", l.Len(), other.Len()))
+	}
+
+	l.Sort(cmp.Compare[int])
+	if s := l.ToSlice(); !slices.IsSorted(s) {
+		panic("sort: list is not sorted")
+	}
+	l.Reverse()
+	l.RemoveIf(func(v int) bool { return v%2 == 0 })
+
+	// элемент чужого списка: в обычной сборке Remove молча ничего не делает
+	err := catch(func() { l.Remove(other.Front()) })
+	if !errors.Is(err, list.ErrInvalid) {
+		panic(fmt.Sprintf("foreign remove: want ErrInvalid, got %v", err))
+	}
+	fmt.Printf("foreign remove: %v\n", err)
+
+	fmt.Printf("debug checks passed, len %d\n", l.Len())
+}
+
+// catch возвращает ошибку, с которой паникует fn.
+func catch(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+	fn()
+	return nil
+}
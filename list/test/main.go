@@ -0,0 +1,458 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/xyersh/xuyacs/list"
+)
+
+// churn имитирует очередь с высокой оборачиваемостью: вставка в конец, удаление из начала.
+func churn(l *list.List[int], n int) {
+	for i := 0; i < 64; i++ {
+		l.PushBack(i)
+	}
+	for i := 0; i < n; i++ {
+		l.PushBack(i)
+		l.Remove(l.Front())
+	}
+}
+
+func main() {
+	formatDemo()
+	cursorDemo()
+	checkInsert()
+	checkOps()
+	checkSkipList()
+	checkRingBuffer()
+	checkPriorityQueues()
+
+	variants := []struct {
+		name string
+		new  func() *list.List[int]
+	}{
+		{"plain", list.New[int]},
+		{"pool", func() *list.List[int] { return list.NewWithPool(list.NewPool[int]()) }},
+		{"arena(256)", func() *list.List[int] { return list.NewWithPool(list.NewArena[int](256)) }},
+	}
+
+	for _, v := range variants {
+		res := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			l := v.new()
+			b.ResetTimer()
+			churn(l, b.N)
+		})
+		fmt.Printf("%-12s %s %s\n", v.name, res.String(), res.MemString())
+	}
+
+	// заполнение с нуля: арена выделяет элементы блоками
+	for _, v := range variants {
+		res := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				l := v.new()
+				for j := 0; j < 1024; j++ {
+					l.PushBack(j)
+				}
+			}
+		})
+		fmt.Printf("fill %-12s %s %s\n", v.name, res.String(), res.MemString())
+	}
+}
+
+// cursorDemo проверяет, что курсор не выдает элемент, переиспользованный пулом.
+func cursorDemo() {
+	l := list.NewWithPool(list.NewPool[int]())
+	l.PushBack(1)
+	l.PushBack(2)
+
+	c := l.Cursor()
+	c.Next()
+	c.Remove()
+	l.PushBack(99) // берет из пула только что удаленный элемент
+	if e := c.Element(); e != nil {
+		panic(fmt.Sprintf("cursor: removed element reused with value %d", e.Value))
+	}
+
+	c.Next()
+	fmt.Printf("cursor after remove: %d, list %v\n", c.Value(), l)
+}
+
+// expectList паникует, если содержимое списка в обоих направлениях не совпадает с want.
+func expectList[T comparable](what string, l *list.List[T], want ...T) {
+	back := slices.Collect(l.Backward())
+	slices.Reverse(back)
+	if got := l.ToSlice(); !slices.Equal(got, want) || !slices.Equal(back, want) || l.Len() != len(want) {
+		panic(fmt.Sprintf("%s: got %v (backward %v, len %d), want %v", what, got, back, l.Len(), want))
+	}
+}
+
+// checkInsert проверяет вставку относительно элемента и вставку копий списков,
+// в том числе копии списка в самого себя.
+func checkInsert() {
+	l := list.FromSlice([]int{1, 3})
+	two := l.InsertAfter(2, l.Front())
+	l.InsertBefore(0, l.Front())
+	l.InsertAfter(4, l.Back())
+	l.InsertBefore(25, l.Back())
+	l.InsertAfter(21, two)
+	expectList("insert", l, 0, 1, 2, 21, 3, 25, 4)
+
+	// с тегом xuyacsdebug чужой mark вызывает панику, без него — возврат nil
+	other := list.FromSlice([]int{7})
+	for _, insert := range []func(int, *list.Element[int]) *list.Element[int]{l.InsertBefore, l.InsertAfter} {
+		accepted := func() bool {
+			defer func() { recover() }()
+			return insert(-1, other.Front()) != nil
+		}()
+		if accepted {
+			panic("insert: foreign mark accepted")
+		}
+	}
+	expectList("insert foreign", l, 0, 1, 2, 21, 3, 25, 4)
+
+	l = list.FromSlice([]int{1, 2})
+	l.PushBackList(list.FromSlice([]int{3, 4}))
+	l.PushFrontList(list.FromSlice([]int{-1, 0}))
+	l.PushBackList(list.New[int]())
+	expectList("push list", l, -1, 0, 1, 2, 3, 4)
+
+	// копия списка в самого себя не зацикливается
+	l = list.FromSlice([]int{1, 2, 3})
+	l.PushBackList(l)
+	expectList("self push back", l, 1, 2, 3, 1, 2, 3)
+	l = list.FromSlice([]int{1, 2, 3})
+	l.PushFrontList(l)
+	expectList("self push front", l, 1, 2, 3, 1, 2, 3)
+
+	// нулевое значение списка готово к использованию
+	var empty list.List[int]
+	empty.PushFrontList(list.FromSlice([]int{5, 6}))
+	expectList("push list into zero value", &empty, 5, 6)
+
+	pooled := list.NewWithPool(list.NewPool[int]())
+	pooled.PushBack(1)
+	pooled.PushBackList(pooled)
+	pooled.PushFrontList(pooled)
+	expectList("self push with pool", pooled, 1, 1, 1, 1)
+	fmt.Println("insert: matches expected order")
+}
+
+// pair — значение с порядковым номером для проверки устойчивости Sort.
+type pair struct{ key, seq int }
+
+// checkOps сверяет Find, Map, Filter, Sort, Splice и RemoveIf со срезом.
+func checkOps() {
+	rnd := rand.New(rand.NewPCG(7, 8))
+	for round := 0; round < 200; round++ {
+		n := rnd.IntN(40)
+		ref := make([]pair, n)
+		for i := range ref {
+			ref[i] = pair{rnd.IntN(10), i}
+		}
+		l := list.FromSlice(ref)
+
+		key := rnd.IntN(10)
+		want := slices.IndexFunc(ref, func(p pair) bool { return p.key == key })
+		if e := l.Find(func(p pair) bool { return p.key == key }); (e == nil) != (want < 0) || e != nil && e.Value != ref[want] {
+			panic(fmt.Sprintf("ops: Find(%d) = %v, want index %d in %v", key, e, want, ref))
+		}
+
+		var keys []int
+		for _, p := range ref {
+			keys = append(keys, p.key)
+		}
+		expectList("ops: Map", list.Map(l, func(p pair) int { return p.key }), keys...)
+
+		odd := func(p pair) bool { return p.key%2 == 1 }
+		var wantOdd []pair
+		for _, p := range ref {
+			if odd(p) {
+				wantOdd = append(wantOdd, p)
+			}
+		}
+		expectList("ops: Filter", list.Filter(l, odd), wantOdd...)
+
+		// элементы до сортировки остаются теми же объектами
+		elems := slices.Collect(l.Elements())
+		byKey := func(a, b pair) int { return cmp.Compare(a.key, b.key) }
+		l.Sort(byKey)
+		slices.SortStableFunc(ref, byKey)
+		expectList("ops: Sort", l, ref...)
+		for _, e := range elems {
+			if l.Find(func(p pair) bool { return p == e.Value }) != e {
+				panic("ops: Sort replaced an element")
+			}
+		}
+
+		// Splice из другого списка: диапазон [i, j] переносится после позиции at
+		src := list.FromSlice([]pair{{-1, 0}, {-2, 1}, {-3, 2}, {-4, 3}})
+		srcRef := src.ToSlice()
+		if n > 0 {
+			i := rnd.IntN(len(srcRef))
+			j := i + rnd.IntN(len(srcRef)-i)
+			at := rnd.IntN(n)
+			l.Splice(elemAt(l, at), src, elemAt(src, i), elemAt(src, j))
+			moved := slices.Clone(srcRef[i : j+1])
+			ref = slices.Insert(ref, at+1, moved...)
+			srcRef = slices.Delete(srcRef, i, j+1)
+			expectList("ops: Splice", l, ref...)
+			expectList("ops: Splice source", src, srcRef...)
+			// перенесенные элементы принадлежат l: вставка рядом с ними работает
+			for _, p := range moved {
+				e := l.Find(func(q pair) bool { return q == p })
+				if l.InsertAfter(p, e) == nil {
+					panic("ops: spliced element does not belong to the list")
+				}
+				l.Remove(e.Next())
+			}
+		}
+
+		// Splice внутри списка: диапазон вне mark переносится, иначе список не меняется
+		if len(ref) > 1 {
+			i := rnd.IntN(len(ref))
+			j := i + rnd.IntN(len(ref)-i)
+			at := rnd.IntN(len(ref))
+			l.Splice(elemAt(l, at), l, elemAt(l, i), elemAt(l, j))
+			if at < i || at > j {
+				moved := slices.Clone(ref[i : j+1])
+				ref = slices.Delete(ref, i, j+1)
+				if at > j {
+					at -= len(moved)
+				}
+				ref = slices.Insert(ref, at+1, moved...)
+			}
+			expectList("ops: self Splice", l, ref...)
+		}
+
+		removed := l.RemoveIf(odd)
+		wantLen := len(ref)
+		ref = slices.DeleteFunc(ref, odd)
+		if removed != wantLen-len(ref) {
+			panic(fmt.Sprintf("ops: RemoveIf removed %d, want %d", removed, wantLen-len(ref)))
+		}
+		expectList("ops: RemoveIf", l, ref...)
+	}
+	fmt.Println("ops: match slice reference")
+}
+
+// elemAt возвращает i-й элемент списка.
+func elemAt[T any](l *list.List[T], i int) *list.Element[T] {
+	e := l.Front()
+	for ; i > 0; i-- {
+		e = e.Next()
+	}
+	return e
+}
+
+func formatDemo() {
+	l := list.FromSlice([]int{3, 1, 2})
+	fmt.Printf("%v\n", l)
+	fmt.Printf("%02d\n", l)
+	fmt.Printf("%+v", l)
+
+	data, _ := json.Marshal(l)
+	fmt.Printf("json: %s\n", data)
+
+	restored := list.Collect(slices.Values([]int{0}))
+	if err := json.Unmarshal([]byte("[5, 6, 7]"), restored); err != nil {
+		panic(err)
+	}
+	fmt.Printf("restored: %v  slice: %v\n", restored, restored.ToSlice())
+
+	// gob: список внутри структуры, декодирование поверх непустого списка
+	type payload struct {
+		Name  string
+		Items *list.List[string]
+	}
+	var buf bytes.Buffer
+	in := payload{Name: "queue", Items: list.FromSlice([]string{"a", "b", "c"})}
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		panic(err)
+	}
+	out := payload{Items: list.FromSlice([]string{"stale"})}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		panic(err)
+	}
+	expectList("gob", out.Items, "a", "b", "c")
+	fmt.Printf("gob: %s %v\n", out.Name, out.Items)
+}
+
+// checkSkipList сверяет список с пропусками с map и отсортированным срезом ключей
+// после случайных вставок и удалений: пролеты должны давать верные Rank и At.
+func checkSkipList() {
+	rnd := rand.New(rand.NewPCG(5, 6))
+	sl := list.NewSkipList[int, int]()
+	ref := make(map[int]int)
+
+	for op := 0; op < 5000; op++ {
+		k := rnd.IntN(500)
+		if rnd.IntN(3) == 0 {
+			_, had := ref[k]
+			if sl.Delete(k) != had {
+				panic(fmt.Sprintf("skiplist: Delete(%d) != %t", k, had))
+			}
+			delete(ref, k)
+		} else {
+			sl.Set(k, op)
+			ref[k] = op
+		}
+
+		if op%100 != 0 {
+			continue
+		}
+		keys := slices.Sorted(maps.Keys(ref))
+		if sl.Len() != len(keys) {
+			panic(fmt.Sprintf("skiplist: len %d, want %d", sl.Len(), len(keys)))
+		}
+		for i, k := range keys {
+			if r, ok := sl.Rank(k); !ok || r != i {
+				panic(fmt.Sprintf("skiplist: Rank(%d) = %d, %t, want %d", k, r, ok, i))
+			}
+			if ak, av, ok := sl.At(i); !ok || ak != k || av != ref[k] {
+				panic(fmt.Sprintf("skiplist: At(%d) = %d, %d, want %d, %d", i, ak, av, k, ref[k]))
+			}
+		}
+		var back []int
+		for k := range sl.Backward() {
+			back = append(back, k)
+		}
+		slices.Reverse(back)
+		if !slices.Equal(back, keys) {
+			panic("skiplist: Backward order mismatch")
+		}
+	}
+
+	var inRange []int
+	for k := range sl.Range(100, 200) {
+		inRange = append(inRange, k)
+	}
+	want := slices.DeleteFunc(slices.Sorted(maps.Keys(ref)), func(k int) bool { return k < 100 || k >= 200 })
+	if !slices.Equal(inRange, want) {
+		panic("skiplist: Range mismatch")
+	}
+	fmt.Printf("skiplist: %d keys, %d in [100, 200), matches map\n", sl.Len(), len(inRange))
+}
+
+// checkRingBuffer сверяет кольцевой буфер со срезом, хранящим последние элементы,
+// при многократном проходе головы через конец среза.
+func checkRingBuffer() {
+	rnd := rand.New(rand.NewPCG(7, 8))
+	for _, policy := range []list.RingPolicy{list.OverwriteOldest, list.RejectWhenFull} {
+		rb := list.NewRingBuffer[int](7, policy)
+		var ref []int
+
+		for op := 0; op < 2000; op++ {
+			if rnd.IntN(4) == 0 {
+				v, ok := rb.Pop()
+				if ok != (len(ref) > 0) || ok && v != ref[0] {
+					panic(fmt.Sprintf("ring: Pop = %d, %t, ref %v", v, ok, ref))
+				}
+				if ok {
+					ref = ref[1:]
+				}
+			} else {
+				full := len(ref) == rb.Cap()
+				if rb.Push(op) != (!full || policy == list.OverwriteOldest) {
+					panic(fmt.Sprintf("ring: Push with full=%t returned wrong result", full))
+				}
+				switch {
+				case !full:
+					ref = append(ref, op)
+				case policy == list.OverwriteOldest:
+					ref = append(ref[1:], op)
+				}
+			}
+
+			if got := slices.Collect(rb.All()); rb.Len() != len(ref) || !slices.Equal(got, ref) {
+				panic(fmt.Sprintf("ring: contents %v, want %v", got, ref))
+			}
+			for i, v := range ref {
+				if got, ok := rb.At(i); !ok || got != v {
+					panic(fmt.Sprintf("ring: At(%d) = %d, want %d", i, got, v))
+				}
+			}
+		}
+	}
+	fmt.Println("ring buffer: overwrite and reject match reference")
+}
+
+// checkPriorityQueues сверяет очереди с приоритетом с отсортированным срезом,
+// в том числе после Update и Remove хендлов из середины кучи.
+func checkPriorityQueues() {
+	rnd := rand.New(rand.NewPCG(9, 10))
+
+	pq := list.NewPriorityQueue(cmp.Compare[int])
+	var ref []int
+	for i := 0; i < 1000; i++ {
+		v := rnd.IntN(100)
+		pq.Push(v)
+		ref = append(ref, v)
+	}
+	slices.Sort(ref)
+	for _, want := range ref {
+		if v, ok := pq.Pop(); !ok || v != want {
+			panic(fmt.Sprintf("pqueue: Pop = %d, want %d", v, want))
+		}
+	}
+
+	ipq := list.NewIndexedPriorityQueue[int](cmp.Compare[int])
+	live := make(map[*list.Handle[int, int]]bool)
+	var handles []*list.Handle[int, int]
+	for op := 0; op < 5000; op++ {
+		switch {
+		case len(handles) == 0 || rnd.IntN(3) == 0:
+			h := ipq.Push(op, rnd.IntN(1000))
+			handles = append(handles, h)
+			live[h] = true
+		case rnd.IntN(2) == 0:
+			h := handles[rnd.IntN(len(handles))]
+			ipq.Update(h, rnd.IntN(1000))
+		default:
+			// хендл из произвольного места кучи, не только вершина
+			i := rnd.IntN(len(handles))
+			h := handles[i]
+			if v, ok := ipq.Remove(h); !ok || v != h.Value {
+				panic(fmt.Sprintf("ipqueue: Remove(%d) = %d, %t", h.Value, v, ok))
+			}
+			if _, ok := ipq.Remove(h); ok {
+				panic("ipqueue: removed handle removed twice")
+			}
+			delete(live, h)
+			handles = slices.Delete(handles, i, i+1)
+		}
+
+		if ipq.Len() != len(live) {
+			panic(fmt.Sprintf("ipqueue: len %d, want %d", ipq.Len(), len(live)))
+		}
+		if top, ok := ipq.Peek(); ok {
+			for h := range live {
+				if h.Priority() < top.Priority() {
+					panic(fmt.Sprintf("ipqueue: top %d, but %d is lower", top.Priority(), h.Priority()))
+				}
+			}
+		}
+	}
+
+	prev := -1
+	for ipq.Len() > 0 {
+		h, _ := ipq.Pop()
+		if !live[h] || h.Priority() < prev {
+			panic(fmt.Sprintf("ipqueue: drained %d after %d", h.Priority(), prev))
+		}
+		delete(live, h)
+		prev = h.Priority()
+	}
+	if len(live) != 0 {
+		panic(fmt.Sprintf("ipqueue: %d handles lost", len(live)))
+	}
+	fmt.Println("priority queues: match sorted reference")
+}
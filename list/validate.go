@@ -0,0 +1,50 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid — общий признак нарушения инвариантов списка, проверяется через errors.Is.
+var ErrInvalid = errors.New("list: invariant violated")
+
+// Validate обходит кольцо и проверяет инварианты списка:
+// симметрию ссылок prev/next, принадлежность элементов списку и счетчик длины.
+// Возвращает ошибку, оборачивающую ErrInvalid, при первом найденном нарушении.
+func (l *List[T]) Validate() error {
+	if l.root.next == nil || l.root.prev == nil {
+		// неинициализированный список валиден, только если пуст
+		if l.root.next != l.root.prev || l.len != 0 {
+			return fmt.Errorf("%w: uninitialized list with len %d", ErrInvalid, l.len)
+		}
+		return nil
+	}
+
+	n := 0
+	for e := &l.root; ; {
+		next := e.next
+		if next == nil {
+			return fmt.Errorf("%w: nil next at position %d", ErrInvalid, n)
+		}
+		if next.prev != e {
+			return fmt.Errorf("%w: next.prev != e at position %d", ErrInvalid, n)
+		}
+		if next == &l.root {
+			break
+		}
+		if next.list != l {
+			return fmt.Errorf("%w: element at position %d belongs to another list", ErrInvalid, n)
+		}
+
+		// защита от зацикливания в обход стража
+		if n++; n > l.len {
+			return fmt.Errorf("%w: more than len=%d elements in ring", ErrInvalid, l.len)
+		}
+		e = next
+	}
+
+	if n != l.len {
+		return fmt.Errorf("%w: len=%d, but ring has %d elements", ErrInvalid, l.len, n)
+	}
+	return nil
+}
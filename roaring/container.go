@@ -0,0 +1,292 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	arrayMaxSize = 4096 // больше элементов — контейнер хранится как битовая карта
+	bitmapWords  = 1024 // 65536 бит в словах по 64
+)
+
+// container хранит младшие 16 бит значений с одинаковыми старшими 16 битами.
+// Изменяющие методы возвращают контейнер, которым нужно заменить исходный:
+// при переполнении или опустошении меняется его тип.
+type container interface {
+	contains(x uint16) bool
+	add(x uint16) container
+	remove(x uint16) container
+	cardinality() int
+	each(yield func(uint16) bool) bool
+	toBitmap() *bitmapContainer // копия в виде битовой карты
+	clone() container
+}
+
+// arrayContainer — отсортированный массив значений, для разреженных контейнеров.
+type arrayContainer []uint16
+
+func (a arrayContainer) contains(x uint16) bool {
+	_, found := slices.BinarySearch(a, x)
+	return found
+}
+
+func (a arrayContainer) add(x uint16) container {
+	i, found := slices.BinarySearch(a, x)
+	if found {
+		return a
+	}
+	if len(a) >= arrayMaxSize {
+		return a.toBitmap().add(x)
+	}
+	return slices.Insert(a, i, x)
+}
+
+func (a arrayContainer) remove(x uint16) container {
+	if i, found := slices.BinarySearch(a, x); found {
+		return slices.Delete(a, i, i+1)
+	}
+	return a
+}
+
+func (a arrayContainer) cardinality() int { return len(a) }
+
+func (a arrayContainer) each(yield func(uint16) bool) bool {
+	for _, x := range a {
+		if !yield(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{card: len(a)}
+	for _, x := range a {
+		b.words[x/64] |= 1 << (x % 64)
+	}
+	return b
+}
+
+func (a arrayContainer) clone() container { return slices.Clone(a) }
+
+// bitmapContainer — битовая карта на 65536 бит, для плотных контейнеров.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	if !b.contains(x) {
+		b.words[x/64] |= 1 << (x % 64)
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	if b.contains(x) {
+		b.words[x/64] &^= 1 << (x % 64)
+		b.card--
+	}
+	return b.normalize()
+}
+
+func (b *bitmapContainer) cardinality() int { return b.card }
+
+func (b *bitmapContainer) each(yield func(uint16) bool) bool {
+	for i, w := range b.words {
+		for w != 0 {
+			if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) clone() container { return b.toBitmap() }
+
+// recount пересчитывает мощность после пословных операций.
+func (b *bitmapContainer) recount() {
+	b.card = 0
+	for _, w := range b.words {
+		b.card += bits.OnesCount64(w)
+	}
+}
+
+// normalize переводит карту в массив, если элементов стало мало.
+func (b *bitmapContainer) normalize() container {
+	if b.card > arrayMaxSize {
+		return b
+	}
+	a := make(arrayContainer, 0, b.card)
+	b.each(func(x uint16) bool {
+		a = append(a, x)
+		return true
+	})
+	return a
+}
+
+// interval — непрерывный отрезок [start, start+length] в run-контейнере.
+// length хранится на единицу меньше количества значений, как в формате Roaring.
+type interval struct {
+	start, length uint16
+}
+
+func (iv interval) last() uint16 { return iv.start + iv.length }
+
+// runContainer — список отрезков, для значений, идущих подряд.
+// Изменения выполняются через преобразование в массив или карту.
+type runContainer []interval
+
+func (r runContainer) contains(x uint16) bool {
+	i, _ := slices.BinarySearchFunc(r, x, func(iv interval, x uint16) int {
+		switch {
+		case iv.last() < x:
+			return -1
+		case iv.start > x:
+			return 1
+		}
+		return 0
+	})
+	return i < len(r) && r[i].start <= x && x <= r[i].last()
+}
+
+func (r runContainer) add(x uint16) container {
+	if r.contains(x) {
+		return r
+	}
+	return r.toBitmap().normalize().add(x)
+}
+
+func (r runContainer) remove(x uint16) container {
+	if !r.contains(x) {
+		return r
+	}
+	return r.toBitmap().normalize().remove(x)
+}
+
+func (r runContainer) cardinality() int {
+	card := 0
+	for _, iv := range r {
+		card += int(iv.length) + 1
+	}
+	return card
+}
+
+func (r runContainer) each(yield func(uint16) bool) bool {
+	for _, iv := range r {
+		for x := int(iv.start); x <= int(iv.last()); x++ {
+			if !yield(uint16(x)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r runContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, iv := range r {
+		for x := int(iv.start); x <= int(iv.last()); x++ {
+			b.words[x/64] |= 1 << (x % 64)
+		}
+	}
+	b.card = r.cardinality()
+	return b
+}
+
+func (r runContainer) clone() container { return slices.Clone(r) }
+
+// toRuns строит список отрезков по значениям контейнера.
+func toRuns(c container) runContainer {
+	var r runContainer
+	c.each(func(x uint16) bool {
+		if n := len(r); n > 0 && r[n-1].last()+1 == x && r[n-1].last() != 0xffff {
+			r[n-1].length++
+		} else {
+			r = append(r, interval{start: x})
+		}
+		return true
+	})
+	return r
+}
+
+// optimize выбирает самое компактное в сериализованном виде представление.
+func optimize(c container) container {
+	runs := toRuns(c)
+	card := c.cardinality()
+
+	runSize := 2 + 4*len(runs)
+	arraySize := 2 * card
+	bitmapSize := 8 * bitmapWords
+
+	switch {
+	case runSize < min(arraySize, bitmapSize):
+		return runs
+	case card <= arrayMaxSize:
+		if a, ok := c.(arrayContainer); ok {
+			return a
+		}
+		return c.toBitmap().normalize()
+	default:
+		return c.toBitmap()
+	}
+}
+
+// setOp — операция над множествами.
+type setOp int
+
+const (
+	opAnd setOp = iota
+	opOr
+	opXor
+	opAndNot
+)
+
+// apply применяет операцию к паре слов битовых карт.
+func (op setOp) apply(x, y uint64) uint64 {
+	switch op {
+	case opAnd:
+		return x & y
+	case opOr:
+		return x | y
+	case opXor:
+		return x ^ y
+	default:
+		return x &^ y
+	}
+}
+
+// combine применяет операцию к двум контейнерам и возвращает новый контейнер.
+func combine(a, b container, op setOp) container {
+	// для разреженного a пересечение и разность дешевле посчитать поэлементно
+	if aa, ok := a.(arrayContainer); ok && (op == opAnd || op == opAndNot) {
+		res := make(arrayContainer, 0, len(aa))
+		for _, x := range aa {
+			if b.contains(x) == (op == opAnd) {
+				res = append(res, x)
+			}
+		}
+		return res
+	}
+
+	res := a.toBitmap()
+	other := b.toBitmap()
+	for i := range res.words {
+		res.words[i] = op.apply(res.words[i], other.words[i])
+	}
+	res.recount()
+	return res.normalize()
+}
@@ -0,0 +1,192 @@
+// Package roaring реализует сжатые битовые карты Roaring для множеств 32-битных чисел.
+// Старшие 16 бит значения выбирают контейнер, младшие хранятся в нем
+// как массив, битовая карта или список отрезков — в зависимости от плотности.
+package roaring
+
+import (
+	"iter"
+	"slices"
+)
+
+type BitmapI interface {
+	Add(x uint32)           // добавить значение
+	Remove(x uint32)        // удалить значение
+	Contains(x uint32) bool // проверить наличие значения
+	Cardinality() uint64    // количество значений
+	All() iter.Seq[uint32]  // значения по возрастанию
+}
+
+var _ BitmapI = (*Bitmap)(nil)
+
+// Bitmap — сжатое множество 32-битных чисел.
+// Нулевое значение Bitmap — пустое множество, готовое к использованию.
+type Bitmap struct {
+	keys       []uint16    // старшие 16 бит, по возрастанию
+	containers []container // контейнеры для соответствующих ключей
+}
+
+// New создает пустую битовую карту.
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// Of создает битовую карту из перечисленных значений.
+func Of(values ...uint32) *Bitmap {
+	b := New()
+	for _, x := range values {
+		b.Add(x)
+	}
+	return b
+}
+
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+// find возвращает позицию ключа и признак его наличия.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	return slices.BinarySearch(b.keys, key)
+}
+
+// Add добавляет значение.
+func (b *Bitmap) Add(x uint32) {
+	key, low := split(x)
+	i, found := b.find(key)
+	if found {
+		b.containers[i] = b.containers[i].add(low)
+		return
+	}
+	b.keys = slices.Insert(b.keys, i, key)
+	b.containers = slices.Insert(b.containers, i, container(arrayContainer{low}))
+}
+
+// Remove удаляет значение.
+func (b *Bitmap) Remove(x uint32) {
+	key, low := split(x)
+	i, found := b.find(key)
+	if !found {
+		return
+	}
+	if c := b.containers[i].remove(low); c.cardinality() > 0 {
+		b.containers[i] = c
+	} else {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+}
+
+// Contains сообщает, есть ли значение в карте.
+func (b *Bitmap) Contains(x uint32) bool {
+	key, low := split(x)
+	i, found := b.find(key)
+	return found && b.containers[i].contains(low)
+}
+
+// Cardinality возвращает количество значений.
+func (b *Bitmap) Cardinality() uint64 {
+	var card uint64
+	for _, c := range b.containers {
+		card += uint64(c.cardinality())
+	}
+	return card
+}
+
+// IsEmpty сообщает, пуста ли карта.
+func (b *Bitmap) IsEmpty() bool { return len(b.keys) == 0 }
+
+// All возвращает итератор по значениям по возрастанию.
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.containers {
+			high := uint32(b.keys[i]) << 16
+			if !c.each(func(low uint16) bool { return yield(high | uint32(low)) }) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice возвращает значения в виде отсортированного среза.
+func (b *Bitmap) ToSlice() []uint32 {
+	return slices.AppendSeq(make([]uint32, 0, b.Cardinality()), b.All())
+}
+
+// Clone возвращает глубокую копию карты.
+func (b *Bitmap) Clone() *Bitmap {
+	res := &Bitmap{
+		keys:       slices.Clone(b.keys),
+		containers: make([]container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		res.containers[i] = c.clone()
+	}
+	return res
+}
+
+// Equal сообщает, совпадают ли множества значений.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if !slices.Equal(b.keys, other.keys) {
+		return false
+	}
+	for i, c := range b.containers {
+		oc := other.containers[i]
+		if c.cardinality() != oc.cardinality() || combine(c, oc, opXor).cardinality() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RunOptimize переводит каждый контейнер в самое компактное представление,
+// в том числе в списки отрезков для значений, идущих подряд.
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// And возвращает пересечение карт.
+func And(x, y *Bitmap) *Bitmap { return apply(x, y, opAnd) }
+
+// Or возвращает объединение карт.
+func Or(x, y *Bitmap) *Bitmap { return apply(x, y, opOr) }
+
+// Xor возвращает симметрическую разность карт.
+func Xor(x, y *Bitmap) *Bitmap { return apply(x, y, opXor) }
+
+// AndNot возвращает значения x, которых нет в y.
+func AndNot(x, y *Bitmap) *Bitmap { return apply(x, y, opAndNot) }
+
+// apply сливает списки ключей двух карт, применяя op к контейнерам с общими ключами.
+func apply(x, y *Bitmap, op setOp) *Bitmap {
+	res := New()
+	push := func(key uint16, c container) {
+		if c.cardinality() > 0 {
+			res.keys = append(res.keys, key)
+			res.containers = append(res.containers, c)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x.keys) || j < len(y.keys) {
+		switch {
+		case j == len(y.keys) || (i < len(x.keys) && x.keys[i] < y.keys[j]):
+			// ключ есть только в x
+			if op != opAnd {
+				push(x.keys[i], x.containers[i].clone())
+			}
+			i++
+		case i == len(x.keys) || y.keys[j] < x.keys[i]:
+			// ключ есть только в y
+			if op == opOr || op == opXor {
+				push(y.keys[j], y.containers[j].clone())
+			}
+			j++
+		default:
+			push(x.keys[i], combine(x.containers[i], y.containers[j], op))
+			i++
+			j++
+		}
+	}
+	return res
+}
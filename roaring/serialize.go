@@ -0,0 +1,267 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Константы переносимого формата Roaring (RoaringFormatSpec).
+const (
+	serialCookieNoRun = 12346 // формат без run-контейнеров
+	serialCookie      = 12347 // формат с run-контейнерами
+	noOffsetThreshold = 4     // при меньшем числе контейнеров с run-cookie смещения не пишутся
+)
+
+// ErrInvalidFormat возвращается при чтении данных не в формате Roaring.
+var ErrInvalidFormat = errors.New("roaring: invalid format")
+
+// WriteTo пишет карту в переносимом формате Roaring, совместимом с
+// CRoaring, Java RoaringBitmap и github.com/RoaringBitmap/roaring.
+func (b *Bitmap) WriteTo(w io.Writer) (int64, error) {
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// MarshalBinary кодирует карту в переносимом формате Roaring.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	var (
+		buf    bytes.Buffer
+		size   = len(b.keys)
+		hasRun = false
+	)
+	for _, c := range b.containers {
+		if _, ok := c.(runContainer); ok {
+			hasRun = true
+			break
+		}
+	}
+
+	le := binary.LittleEndian
+	writeU16 := func(v uint16) { buf.Write(le.AppendUint16(nil, v)) }
+	writeU32 := func(v uint32) { buf.Write(le.AppendUint32(nil, v)) }
+
+	// заголовок: cookie и, для формата с run, битсет run-контейнеров
+	headerSize := 0
+	if hasRun {
+		writeU32(serialCookie | uint32(size-1)<<16)
+		runBits := make([]byte, (size+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(runContainer); ok {
+				runBits[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf.Write(runBits)
+		headerSize = 4 + len(runBits)
+	} else {
+		writeU32(serialCookieNoRun)
+		writeU32(uint32(size))
+		headerSize = 8
+	}
+
+	// описание контейнеров: ключ и мощность-1
+	for i, c := range b.containers {
+		writeU16(b.keys[i])
+		writeU16(uint16(c.cardinality() - 1))
+	}
+	headerSize += 4 * size
+
+	// смещения контейнеров от начала потока
+	if !hasRun || size >= noOffsetThreshold {
+		offset := headerSize + 4*size
+		for _, c := range b.containers {
+			writeU32(uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case arrayContainer:
+			for _, x := range c {
+				writeU16(x)
+			}
+		case *bitmapContainer:
+			for _, w := range c.words {
+				buf.Write(le.AppendUint64(nil, w))
+			}
+		case runContainer:
+			writeU16(uint16(len(c)))
+			for _, iv := range c {
+				writeU16(iv.start)
+				writeU16(iv.length)
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// serializedSize возвращает размер контейнера в переносимом формате.
+func serializedSize(c container) int {
+	switch c := c.(type) {
+	case arrayContainer:
+		return 2 * len(c)
+	case runContainer:
+		return 2 + 4*len(c)
+	default:
+		return 8 * bitmapWords
+	}
+}
+
+// ReadFrom читает карту в переносимом формате Roaring, заменяя текущее содержимое.
+// Reader читается до конца, поэтому карта должна быть последней в потоке.
+func (b *Bitmap) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(data)), err
+	}
+	return int64(len(data)), b.UnmarshalBinary(data)
+}
+
+// UnmarshalBinary декодирует карту из переносимого формата Roaring.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	rd := &reader{data: data}
+
+	cookie := rd.u32()
+	var (
+		size    int
+		runBits []byte
+	)
+	switch {
+	case cookie&0xffff == serialCookie:
+		size = int(cookie>>16) + 1
+		runBits = rd.bytes((size + 7) / 8)
+	case cookie == serialCookieNoRun:
+		size = int(rd.u32())
+	default:
+		return fmt.Errorf("%w: unknown cookie %d", ErrInvalidFormat, cookie)
+	}
+	if rd.err != nil || size > 1<<16 {
+		return fmt.Errorf("%w: bad header", ErrInvalidFormat)
+	}
+
+	keys := make([]uint16, size)
+	cards := make([]int, size)
+	for i := range size {
+		keys[i] = rd.u16()
+		cards[i] = int(rd.u16()) + 1
+		if rd.err == nil && i > 0 && keys[i] <= keys[i-1] {
+			return fmt.Errorf("%w: keys not strictly increasing at %d", ErrInvalidFormat, i)
+		}
+	}
+
+	// смещения не нужны: контейнеры идут подряд
+	if runBits == nil || size >= noOffsetThreshold {
+		rd.bytes(4 * size)
+	}
+
+	containers := make([]container, size)
+	for i := range size {
+		isRun := runBits != nil && runBits[i/8]&(1<<(i%8)) != 0
+		switch {
+		case isRun:
+			runs := make(runContainer, rd.u16())
+			for j := range runs {
+				runs[j] = interval{start: rd.u16(), length: rd.u16()}
+			}
+			containers[i] = runs
+		case cards[i] > arrayMaxSize:
+			bc := &bitmapContainer{}
+			for j := range bc.words {
+				bc.words[j] = rd.u64()
+			}
+			bc.recount()
+			containers[i] = bc
+		default:
+			ac := make(arrayContainer, cards[i])
+			for j := range ac {
+				ac[j] = rd.u16()
+			}
+			containers[i] = ac
+		}
+	}
+	if rd.err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, rd.err)
+	}
+	for i, c := range containers {
+		if err := validateContainer(c, cards[i]); err != nil {
+			return fmt.Errorf("%w: container %d (key %d): %v", ErrInvalidFormat, i, keys[i], err)
+		}
+	}
+
+	b.keys, b.containers = keys, containers
+	return nil
+}
+
+// validateContainer проверяет, что прочитанный контейнер упорядочен
+// и его мощность совпадает с объявленной в заголовке.
+// Иначе бинарный поиск в Contains и Cardinality дадут неверный результат.
+func validateContainer(c container, card int) error {
+	switch c := c.(type) {
+	case arrayContainer:
+		for j := 1; j < len(c); j++ {
+			if c[j] <= c[j-1] {
+				return fmt.Errorf("array values not strictly increasing at %d", j)
+			}
+		}
+	case runContainer:
+		for j, iv := range c {
+			if int(iv.start)+int(iv.length) > 0xffff {
+				return fmt.Errorf("run %d overflows 16 bits", j)
+			}
+			if j > 0 && iv.start <= c[j-1].last() {
+				return fmt.Errorf("runs not sorted or overlapping at %d", j)
+			}
+		}
+	}
+	if got := c.cardinality(); got != card {
+		return fmt.Errorf("cardinality %d, header says %d", got, card)
+	}
+	return nil
+}
+
+// reader последовательно читает little-endian значения и запоминает первую ошибку.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) u16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) u32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) u64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
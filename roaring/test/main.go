@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/xyersh/xuyacs/roaring"
+)
+
+func main() {
+	users := roaring.Of(1, 2, 3, 1_000_000, 4_000_000_000)
+	for id := uint32(100); id < 200; id++ {
+		users.Add(id)
+	}
+	users.RunOptimize()
+
+	admins := roaring.Of(2, 150, 1_000_000, 7)
+
+	fmt.Printf("users: %d   admins: %d\n", users.Cardinality(), admins.Cardinality())
+	fmt.Printf("and: %v\n", roaring.And(users, admins).ToSlice())
+	fmt.Printf("andnot: %v\n", roaring.AndNot(admins, users).ToSlice())
+	fmt.Printf("contains 4e9: %t\n", users.Contains(4_000_000_000))
+
+	var buf bytes.Buffer
+	users.WriteTo(&buf)
+	fmt.Printf("serialized: %d bytes\n", buf.Len())
+
+	restored := roaring.New()
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		panic(err)
+	}
+	fmt.Printf("restored equal: %t\n", restored.Equal(users))
+
+	checkFormat()
+	checkSpecFiles(os.Args[1:])
+}
+
+// Байтовые векторы, собранные вручную по описанию формата в RoaringFormatSpec.
+var formatVectors = []struct {
+	name string
+	hex  string
+	want []uint32
+}{
+	{
+		// cookie 12346, 1 контейнер, ключ 0, мощность-1 = 2, смещение 16, значения
+		"array, no runs",
+		"3a300000 01000000 00000200 10000000 010002000300",
+		[]uint32{1, 2, 3},
+	},
+	{
+		// два контейнера-массива с ключами 0 и 1, смещения 24 и 26
+		"two keys, no runs",
+		"3a300000 02000000 00000000 01000000 18000000 1a000000 0100 0500",
+		[]uint32{1, 1<<16 | 5},
+	},
+	{
+		// cookie 12347 | (1-1)<<16, битсет run-контейнеров, без смещений (< 4 контейнеров),
+		// 1 отрезок: начало 10, длина-1 = 10
+		"run, no offsets",
+		"3b300000 01 00000a00 0100 0a00 0a00",
+		seq(10, 21),
+	},
+	{
+		// 4 run-контейнера: смещения пишутся, первое — 4+1+16+16 = 37
+		"runs with offsets",
+		"3b300300 0f 00000900 01000900 02000900 03000900 25000000 2b000000 31000000 37000000" +
+			" 010000000900 010000000900 010000000900 010000000900",
+		slices.Concat(seq(0, 10), seq(1<<16, 1<<16+10), seq(2<<16, 2<<16+10), seq(3<<16, 3<<16+10)),
+	},
+}
+
+// Поврежденные данные, которые должны отклоняться.
+var invalidVectors = []struct {
+	name string
+	hex  string
+}{
+	{"keys not increasing", "3a300000 02000000 01000000 00000000 18000000 1a000000 0100 0500"},
+	{"array not sorted", "3a300000 01000000 00000200 10000000 030002000100"},
+	{"run overflows 16 bits", "3b300000 01 00002000 0100 f0ff 2000"},
+	{"cardinality mismatch", "3b300000 01 00000b00 0100 0a00 0a00"},
+	{"truncated", "3a300000 01000000 00000200 10000000 0100"},
+}
+
+func seq(from, to uint32) []uint32 {
+	var res []uint32
+	for x := from; x < to; x++ {
+		res = append(res, x)
+	}
+	return res
+}
+
+func decodeHex(s string) []byte {
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// checkFormat декодирует эталонные векторы и проверяет, что кодирование дает те же байты.
+func checkFormat() {
+	for _, v := range formatVectors {
+		data := decodeHex(v.hex)
+
+		b := roaring.New()
+		if err := b.UnmarshalBinary(data); err != nil {
+			panic(fmt.Sprintf("%s: %v", v.name, err))
+		}
+		if got := b.ToSlice(); !slices.Equal(got, v.want) {
+			panic(fmt.Sprintf("%s: decoded %v, want %v", v.name, got, v.want))
+		}
+
+		enc, _ := b.MarshalBinary()
+		if !bytes.Equal(enc, data) {
+			panic(fmt.Sprintf("%s: encoded %x, want %x", v.name, enc, data))
+		}
+	}
+
+	for _, v := range invalidVectors {
+		err := roaring.New().UnmarshalBinary(decodeHex(v.hex))
+		if !errors.Is(err, roaring.ErrInvalidFormat) {
+			panic(fmt.Sprintf("%s: accepted, err %v", v.name, err))
+		}
+	}
+	fmt.Printf("format: %d vectors decoded, %d invalid rejected\n", len(formatVectors), len(invalidVectors))
+}
+
+// checkSpecFiles сверяет файлы bitmapwithruns.bin и bitmapwithoutruns.bin из testdata
+// RoaringFormatSpec с множеством, по которому они были сгенерированы:
+//
+//	go run ./roaring/test path/to/bitmapwithruns.bin path/to/bitmapwithoutruns.bin
+func checkSpecFiles(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	want := roaring.New()
+	for k := uint32(0); k < 100000; k += 1000 {
+		want.Add(k)
+	}
+	for k := uint32(100000); k < 200000; k++ {
+		want.Add(3 * k)
+	}
+	for k := uint32(700000); k < 800000; k++ {
+		want.Add(k)
+	}
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			panic(err)
+		}
+		b := roaring.New()
+		_, err = b.ReadFrom(f)
+		f.Close()
+		if err != nil {
+			panic(fmt.Sprintf("%s: %v", path, err))
+		}
+		if !b.Equal(want) {
+			panic(fmt.Sprintf("%s: contents differ from the spec generator", path))
+		}
+		fmt.Printf("%s: %d values, matches spec\n", path, b.Cardinality())
+	}
+}